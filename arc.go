@@ -0,0 +1,457 @@
+package gcache
+
+import (
+	"container/list"
+	"time"
+)
+
+// ARCCache represents a cache which uses Adaptive Replacement Cache (ARC)
+// as its eviction policy. ARC balances between recency and frequency by
+// tracking two LRU lists of live entries (t1, t2) and two ghost lists of
+// evicted keys (b1, b2), adapting the target size of t1 as hits land in
+// the ghost lists.
+type ARCCache struct {
+	baseCache
+	items map[interface{}]*arcItem
+
+	part int // target size for t1 ("p" in the ARC paper)
+	t1   *list.List
+	b1   *list.List
+	t2   *list.List
+	b2   *list.List
+
+	// b1Index and b2Index back b1/b2 with O(1) membership lookups,
+	// keyed the same way as items, instead of scanning the ghost lists.
+	b1Index map[interface{}]*list.Element
+	b2Index map[interface{}]*list.Element
+}
+
+// NewARC returns new ARC cache instance
+func NewARC(config Config) *ARCCache {
+	return newARCCache(config)
+}
+
+func newARCCache(config Config) *ARCCache {
+	c := &ARCCache{}
+	buildCache(&c.baseCache, config)
+
+	c.init()
+	c.loadGroup.cache = c
+	return c
+}
+
+func (c *ARCCache) init() {
+	c.part = 0
+	c.items = make(map[interface{}]*arcItem, c.size+1)
+	c.t1 = list.New()
+	c.b1 = list.New()
+	c.t2 = list.New()
+	c.b2 = list.New()
+	c.b1Index = make(map[interface{}]*list.Element, c.size+1)
+	c.b2Index = make(map[interface{}]*list.Element, c.size+1)
+}
+
+// Set a new key-value pair
+func (c *ARCCache) Set(key, value interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.set(key, value)
+	return err
+}
+
+// SetWithTTL Set a new key-value pair with an expiration time
+func (c *ARCCache) SetWithTTL(key, value interface{}, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, err := c.set(key, value)
+	if err != nil {
+		return err
+	}
+
+	t := c.clock.Now().Add(expiration)
+	item.(*arcItem).expiration = &t
+	return nil
+}
+
+func (c *ARCCache) set(key, value interface{}) (interface{}, error) {
+	var err error
+	if c.serializeWith != nil {
+		value, err = c.serializeWith(key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item, ok := c.items[key]
+	if ok {
+		item.value = value
+		c.access(item)
+	} else if el := c.lookupGhost(c.b1Index, key); el != nil {
+		// Frequency hit on a recently-evicted t1 entry: grow t1's share.
+		b2Len := c.b2.Len()
+		if b2Len == 0 {
+			b2Len = 1
+		}
+		delta := c.b1.Len() / b2Len
+		if delta < 1 {
+			delta = 1
+		}
+		c.part += delta
+		if c.part > c.size {
+			c.part = c.size
+		}
+		c.b1.Remove(el)
+		delete(c.b1Index, key)
+		c.replace(false)
+		item = c.newItem(key, value)
+		item.element = c.t2.PushFront(item)
+		item.ls = c.t2
+		c.items[key] = item
+	} else if el := c.lookupGhost(c.b2Index, key); el != nil {
+		// Frequency hit on a recently-evicted t2 entry: shrink t1's share.
+		b1Len := c.b1.Len()
+		if b1Len == 0 {
+			b1Len = 1
+		}
+		delta := c.b2.Len() / b1Len
+		if delta < 1 {
+			delta = 1
+		}
+		c.part -= delta
+		if c.part < 0 {
+			c.part = 0
+		}
+		c.b2.Remove(el)
+		delete(c.b2Index, key)
+		c.replace(true)
+		item = c.newItem(key, value)
+		item.element = c.t2.PushFront(item)
+		item.ls = c.t2
+		c.items[key] = item
+	} else {
+		c.setNew(key, value)
+		item = c.items[key]
+	}
+
+	if c.defaultTTL != nil {
+		t := c.clock.Now().Add(*c.defaultTTL)
+		item.expiration = &t
+	}
+
+	if c.onAdd != nil {
+		c.onAdd(key, value)
+	}
+
+	return item, nil
+}
+
+// setNew inserts a brand-new key into t1, making room first if t1/t2 are
+// already at capacity, per the ARC replacement algorithm.
+func (c *ARCCache) setNew(key, value interface{}) {
+	t1Len, b1Len := c.t1.Len(), c.b1.Len()
+	if t1Len+b1Len == c.size {
+		if t1Len < c.size {
+			c.removeGhostLRU(c.b1, c.b1Index)
+			c.replace(false)
+		} else {
+			c.evictLRU(c.t1)
+		}
+	} else if t1Len+b1Len < c.size {
+		total := t1Len + c.t2.Len() + b1Len + c.b2.Len()
+		if total >= c.size {
+			if total == 2*c.size {
+				c.removeGhostLRU(c.b2, c.b2Index)
+			}
+			c.replace(false)
+		}
+	}
+
+	item := c.newItem(key, value)
+	item.element = c.t1.PushFront(item)
+	item.ls = c.t1
+	c.items[key] = item
+}
+
+// replace evicts the LRU entry of either t1 or t2 into its corresponding
+// ghost list: t1 when it is larger than its target size p (or equal to p
+// on a b2 ghost hit), otherwise t2, falling back to t1 if t2 is empty.
+func (c *ARCCache) replace(inB2 bool) {
+	if c.t1.Len() > 0 && (c.t1.Len() > c.part || (c.t1.Len() == c.part && inB2)) {
+		c.moveLRUToGhost(c.t1, c.b1, c.b1Index)
+	} else if c.t2.Len() > 0 {
+		c.moveLRUToGhost(c.t2, c.b2, c.b2Index)
+	} else if c.t1.Len() > 0 {
+		c.moveLRUToGhost(c.t1, c.b1, c.b1Index)
+	}
+}
+
+func (c *ARCCache) moveLRUToGhost(src, ghost *list.List, ghostIndex map[interface{}]*list.Element) {
+	el := src.Back()
+	if el == nil {
+		return
+	}
+	item := el.Value.(*arcItem)
+	src.Remove(el)
+	delete(c.items, item.key)
+	ghostIndex[item.key] = ghost.PushFront(item.key)
+	c.capGhost(ghost, ghostIndex)
+	if c.onEvict != nil {
+		c.onEvict(item.key, item.value)
+	}
+}
+
+func (c *ARCCache) evictLRU(src *list.List) {
+	el := src.Back()
+	if el == nil {
+		return
+	}
+	item := el.Value.(*arcItem)
+	src.Remove(el)
+	delete(c.items, item.key)
+	if c.onEvict != nil {
+		c.onEvict(item.key, item.value)
+	}
+}
+
+func (c *ARCCache) removeGhostLRU(ghost *list.List, ghostIndex map[interface{}]*list.Element) {
+	el := ghost.Back()
+	if el == nil {
+		return
+	}
+	ghost.Remove(el)
+	delete(ghostIndex, el.Value)
+}
+
+func (c *ARCCache) capGhost(ghost *list.List, ghostIndex map[interface{}]*list.Element) {
+	for ghost.Len() > c.size {
+		c.removeGhostLRU(ghost, ghostIndex)
+	}
+}
+
+// lookupGhost reports whether key is present in a ghost list (b1 or b2)
+// via its backing index map, in O(1) rather than scanning the list.
+func (c *ARCCache) lookupGhost(ghostIndex map[interface{}]*list.Element, key interface{}) *list.Element {
+	return ghostIndex[key]
+}
+
+func (c *ARCCache) newItem(key, value interface{}) *arcItem {
+	return &arcItem{
+		clock: c.clock,
+		key:   key,
+		value: value,
+	}
+}
+
+// access promotes item to t2 MRU on a hit, whether it was seen once (t1)
+// or more than once (t2) before.
+func (c *ARCCache) access(item *arcItem) {
+	item.ls.Remove(item.element)
+	item.element = c.t2.PushFront(item)
+	item.ls = c.t2
+}
+
+// Get a value from cache pool using key if it exists.
+// If it dose not exists key and has LoaderFunc,
+// generate a value using `LoaderFunc` method returns value.
+func (c *ARCCache) Get(key interface{}) (interface{}, error) {
+	v, err := c.get(key, false)
+	if err == ErrKeyNotFound {
+		return c.getWithLoader(key, true)
+	}
+	return v, err
+}
+
+// GetIFPresent gets a value from cache pool using key if it exists.
+// If it does not exist and the cache has a LoaderFunc, it loads the value
+// without waiting on a load already in flight for the same key (returning
+// nil, nil in that case); without a LoaderFunc it returns ErrKeyNotFound.
+func (c *ARCCache) GetIFPresent(key interface{}) (interface{}, error) {
+	v, err := c.get(key, false)
+	if err == ErrKeyNotFound {
+		return c.getWithLoader(key, false)
+	}
+	return v, err
+}
+
+func (c *ARCCache) get(key interface{}, onLoad bool) (interface{}, error) {
+	v, err := c.getValue(key, onLoad)
+	if err != nil {
+		return nil, err
+	}
+	if c.deserializeWith != nil {
+		return c.deserializeWith(key, v)
+	}
+	return v, nil
+}
+
+func (c *ARCCache) getValue(key interface{}, onLoad bool) (interface{}, error) {
+	c.mu.Lock()
+	item, ok := c.items[key]
+	if ok {
+		if !item.IsExpired(nil) {
+			c.access(item)
+			v := item.value
+			c.mu.Unlock()
+			if !onLoad {
+				c.stats.IncrHitCount()
+			}
+			return v, nil
+		}
+		c.removeItem(item)
+	}
+	c.mu.Unlock()
+	if !onLoad {
+		c.stats.IncrMissCount()
+	}
+	return nil, ErrKeyNotFound
+}
+
+func (c *ARCCache) getWithLoader(key interface{}, isWait bool) (interface{}, error) {
+	value, _, err := c.load(key, func(v interface{}, expiration *time.Duration, e error) (interface{}, error) {
+		if e != nil {
+			return nil, e
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		item, err := c.set(key, v)
+		if err != nil {
+			return nil, err
+		}
+		if expiration != nil {
+			t := c.clock.Now().Add(*expiration)
+			item.(*arcItem).expiration = &t
+		}
+		return v, nil
+	}, isWait)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Has checks if key exists in cache
+func (c *ARCCache) Has(key interface{}) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	now := time.Now()
+	return c.has(key, &now)
+}
+
+func (c *ARCCache) has(key interface{}, now *time.Time) bool {
+	item, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	return !item.IsExpired(now)
+}
+
+// Remove removes the provided key from the cache.
+func (c *ARCCache) Remove(key interface{}) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if item, ok := c.items[key]; ok {
+		c.removeItem(item)
+		if c.onDel != nil {
+			c.onDel(item.key, item.value)
+		}
+		return true
+	}
+	return false
+}
+
+func (c *ARCCache) removeItem(item *arcItem) {
+	delete(c.items, item.key)
+	item.ls.Remove(item.element)
+}
+
+func (c *ARCCache) keys() []interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys := make([]interface{}, len(c.items))
+	var i = 0
+	for k := range c.items {
+		keys[i] = k
+		i++
+	}
+	return keys
+}
+
+// GetALL returns all key-value pairs in the cache.
+func (c *ARCCache) GetALL(checkExpired bool) map[interface{}]interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	items := make(map[interface{}]interface{}, len(c.items))
+	now := time.Now()
+	for k, item := range c.items {
+		if !checkExpired || c.has(k, &now) {
+			items[k] = item.value
+		}
+	}
+	return items
+}
+
+// Keys returns a slice of the keys in the cache
+func (c *ARCCache) Keys(checkExpired bool) []interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys := make([]interface{}, 0, len(c.items))
+	now := time.Now()
+	for k := range c.items {
+		if !checkExpired || c.has(k, &now) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Len returns the number of items in the cache
+func (c *ARCCache) Len(checkExpired bool) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !checkExpired {
+		return len(c.items)
+	}
+	var length int
+	now := time.Now()
+	for k := range c.items {
+		if c.has(k, &now) {
+			length++
+		}
+	}
+	return length
+}
+
+// Purge completely clears the cache
+func (c *ARCCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.onPurge != nil {
+		for key, item := range c.items {
+			c.onPurge(key, item.value)
+		}
+	}
+
+	c.init()
+}
+
+type arcItem struct {
+	clock      Clock
+	key        interface{}
+	value      interface{}
+	ls         *list.List
+	element    *list.Element
+	expiration *time.Time
+}
+
+// IsExpired returns boolean value whether this item is expired or not
+func (it *arcItem) IsExpired(now *time.Time) bool {
+	if it.expiration == nil {
+		return false
+	}
+	if now == nil {
+		t := it.clock.Now()
+		now = &t
+	}
+	return it.expiration.Before(*now)
+}
@@ -0,0 +1,56 @@
+package gcache
+
+import "testing"
+
+func TestARCBasicGetSet(t *testing.T) {
+	c := NewARC(Config{Size: 4})
+	if err := c.Set("a", 1); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	v, err := c.getValue("a", false)
+	if err != nil || v != 1 {
+		t.Fatalf("getValue(a) = %v, %v; want 1, nil", v, err)
+	}
+	if _, err := c.getValue("missing", false); err != ErrKeyNotFound {
+		t.Fatalf("getValue(missing) err = %v; want ErrKeyNotFound", err)
+	}
+}
+
+func TestARCGetDoesNotDoubleUnlock(t *testing.T) {
+	c := NewARC(Config{Size: 2})
+	_ = c.Set("a", 1)
+	// Both the hit and the miss path must each unlock exactly once; a
+	// double-unlock here panics with "sync: Unlock of unlocked RWMutex".
+	if _, err := c.getValue("a", false); err != nil {
+		t.Fatalf("unexpected error on hit: %v", err)
+	}
+	if _, err := c.getValue("missing", false); err != ErrKeyNotFound {
+		t.Fatalf("unexpected error on miss: %v", err)
+	}
+}
+
+func TestARCGhostHitsAdaptPart(t *testing.T) {
+	c := NewARC(Config{Size: 4})
+	for _, k := range []string{"a", "b", "c", "d"} {
+		_ = c.Set(k, k)
+	}
+	// Promote "a" into t2 so t1 has room to give one entry to the b1 ghost
+	// list on the next insert.
+	if _, err := c.getValue("a", false); err != nil {
+		t.Fatalf("getValue(a): %v", err)
+	}
+	_ = c.Set("e", "e")
+
+	if c.b1.Len() == 0 {
+		t.Fatalf("expected an entry to land in the b1 ghost list, got none")
+	}
+	partBefore := c.part
+	ghostKey := c.b1.Back().Value
+	_ = c.Set(ghostKey, "touched")
+	if c.part <= partBefore {
+		t.Fatalf("part did not grow on b1 ghost hit: before=%d after=%d", partBefore, c.part)
+	}
+	if _, ok := c.b1Index[ghostKey]; ok {
+		t.Fatalf("key %v should have been removed from b1 once it was reinstated", ghostKey)
+	}
+}
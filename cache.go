@@ -0,0 +1,143 @@
+package gcache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Cache is the common interface implemented by every eviction-policy
+// cache in this package (LFUCache, ARCCache, TwoQueueCache, SieveCache,
+// and ShardedCache, which wraps them).
+type Cache interface {
+	Set(key, value interface{}) error
+	SetWithTTL(key, value interface{}, expiration time.Duration) error
+	Get(key interface{}) (interface{}, error)
+	GetIFPresent(key interface{}) (interface{}, error)
+	GetALL(checkExpired bool) map[interface{}]interface{}
+	Has(key interface{}) bool
+	Remove(key interface{}) bool
+	Purge()
+	Keys(checkExpired bool) []interface{}
+	Len(checkExpired bool) int
+	HitCount() uint64
+	MissCount() uint64
+}
+
+// LoaderFunc loads a value for key on a cache miss, optionally returning
+// a TTL override for the loaded entry.
+type LoaderFunc func(key interface{}) (interface{}, *time.Duration, error)
+
+// SerializeFunc transforms a value before it is stored.
+type SerializeFunc func(key, value interface{}) (interface{}, error)
+
+// DeserializeFunc transforms a stored value before it is returned.
+type DeserializeFunc func(key, value interface{}) (interface{}, error)
+
+// EvictedFunc is called after an item is evicted from or deleted out of
+// the cache.
+type EvictedFunc func(key, value interface{})
+
+// AddedFunc is called after an item is added to or updated in the cache.
+type AddedFunc func(key, value interface{})
+
+// PurgeVisitorFunc is called once per item as Purge tears the cache down.
+type PurgeVisitorFunc func(key, value interface{})
+
+// Clock reports the current time, letting tests inject a fake clock
+// instead of depending on the wall clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the default Clock, backed by the standard library.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// baseCache holds the fields common to every eviction-policy cache in
+// this package: locking, expiration, loader/serializer/callback hooks
+// and hit/miss stats.
+type baseCache struct {
+	mu              sync.RWMutex
+	size            int
+	clock           Clock
+	defaultTTL      *time.Duration
+	loaderFunc      LoaderFunc
+	onAdd           AddedFunc
+	onEvict         EvictedFunc
+	onDel           EvictedFunc
+	onPurge         PurgeVisitorFunc
+	serializeWith   SerializeFunc
+	deserializeWith DeserializeFunc
+	stats           stats
+	loadGroup       Group
+}
+
+// buildCache populates a baseCache's fields from config, applying the
+// package defaults for anything left unset.
+func buildCache(c *baseCache, config Config) {
+	size := config.Size
+	if size <= 0 {
+		size = 1
+	}
+	c.size = size
+	c.clock = config.Clock
+	if c.clock == nil {
+		c.clock = realClock{}
+	}
+	c.defaultTTL = config.DefaultTTL
+	c.loaderFunc = config.LoaderFunc
+	c.onAdd = config.AddedFunc
+	c.onEvict = config.EvictedFunc
+	c.onDel = config.DeletedFunc
+	c.onPurge = config.PurgeVisitorFunc
+	c.serializeWith = config.SerializeFunc
+	c.deserializeWith = config.DeserializeFunc
+}
+
+// load resolves key via LoaderFunc, de-duplicating concurrent loads for
+// the same key through loadGroup, then hands the loaded value/expiration
+// to cb (which is responsible for storing it in the cache). Without a
+// LoaderFunc it returns ErrKeyNotFound.
+func (c *baseCache) load(key interface{}, cb func(interface{}, *time.Duration, error) (interface{}, error), isWait bool) (interface{}, bool, error) {
+	if c.loaderFunc == nil {
+		return nil, false, ErrKeyNotFound
+	}
+	return c.loadGroup.Do(key, func() (interface{}, error) {
+		return cb(c.loaderFunc(key))
+	}, isWait)
+}
+
+// HitCount returns the number of cache hits recorded so far.
+func (c *baseCache) HitCount() uint64 { return c.stats.HitCount() }
+
+// MissCount returns the number of cache misses recorded so far.
+func (c *baseCache) MissCount() uint64 { return c.stats.MissCount() }
+
+// LookupCount returns the total number of Get/GetIFPresent lookups.
+func (c *baseCache) LookupCount() uint64 { return c.stats.HitCount() + c.stats.MissCount() }
+
+// HitRate returns the ratio of hits to lookups, or 0 if there have been none.
+func (c *baseCache) HitRate() float64 { return c.stats.HitRate() }
+
+// stats tracks hit/miss counts with atomics so readers don't need to
+// hold the cache's main mutex.
+type stats struct {
+	hitCount  uint64
+	missCount uint64
+}
+
+func (s *stats) IncrHitCount() uint64  { return atomic.AddUint64(&s.hitCount, 1) }
+func (s *stats) IncrMissCount() uint64 { return atomic.AddUint64(&s.missCount, 1) }
+func (s *stats) HitCount() uint64      { return atomic.LoadUint64(&s.hitCount) }
+func (s *stats) MissCount() uint64     { return atomic.LoadUint64(&s.missCount) }
+
+func (s *stats) HitRate() float64 {
+	hc, mc := s.HitCount(), s.MissCount()
+	total := hc + mc
+	if total == 0 {
+		return 0.0
+	}
+	return float64(hc) / float64(total)
+}
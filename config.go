@@ -0,0 +1,55 @@
+package gcache
+
+import "time"
+
+// Config configures a new cache instance, shared across every eviction
+// policy (LFU, ARC, 2Q, SIEVE) via buildCache/baseCache. Fields left at
+// their zero value fall back to the package's defaults.
+type Config struct {
+	// Size is the maximum number of items the cache holds before
+	// evicting. Values <= 0 are treated as 1.
+	Size int
+
+	// DefaultTTL, if set, is applied to every item added via Set (as
+	// opposed to SetWithTTL, which overrides it per item).
+	DefaultTTL *time.Duration
+
+	// Clock supplies the current time; defaults to the wall clock.
+	Clock Clock
+
+	LoaderFunc       LoaderFunc
+	EvictedFunc      EvictedFunc
+	AddedFunc        AddedFunc
+	DeletedFunc      EvictedFunc
+	PurgeVisitorFunc PurgeVisitorFunc
+	SerializeFunc    SerializeFunc
+	DeserializeFunc  DeserializeFunc
+
+	// MaxCost bounds LFUCache's total size by cumulative item cost
+	// rather than item count; see CostFunc. When set, LFUCache evicts on
+	// cost alone and Size no longer caps its item count. Zero disables
+	// cost-based eviction, leaving Size as the only bound.
+	MaxCost int64
+
+	// CostFunc reports the cost of a key/value pair; it defaults to a
+	// constant 1 (i.e. cost == item count) when nil.
+	CostFunc func(key, value interface{}) int64
+
+	// RecentRatio and GhostRatio tune TwoQueueCache's internal queue
+	// sizes as a fraction of Size. Zero means use the package defaults
+	// (TwoQueueRecentRatio/TwoQueueGhostRatio).
+	RecentRatio float64
+	GhostRatio  float64
+
+	// EvictType selects the eviction policy NewSharded builds each of
+	// its shards with. The zero value defaults to TYPE_LFU.
+	EvictType string
+}
+
+// Eviction policy identifiers for Config.EvictType.
+const (
+	TYPE_LFU   = "lfu"
+	TYPE_ARC   = "arc"
+	TYPE_2Q    = "2q"
+	TYPE_SIEVE = "sieve"
+)
@@ -0,0 +1,8 @@
+package gcache
+
+import "errors"
+
+// ErrKeyNotFound is returned when a key is absent from the cache and
+// either has no LoaderFunc configured or the LoaderFunc could not
+// resolve it.
+var ErrKeyNotFound = errors.New("key not found")
@@ -0,0 +1,124 @@
+package gcache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// This file defines the shared plumbing for gcache's generics-based API:
+// typed counterparts of LFUCache/ARCCache/TwoQueueCache/SieveCache that
+// avoid interface{} boxing on the hot Get/Set path and give loader,
+// serializer and callback hooks a concrete (K, V) signature instead of
+// interface{}, so a bad assertion in one can no longer panic at runtime.
+// The legacy interface{}-based types are untouched; these live alongside
+// them for callers that can afford to fix K/V at compile time.
+
+// GenericLoaderFunc loads a value for key, optionally returning a TTL
+// override for the loaded entry.
+type GenericLoaderFunc[K comparable, V any] func(key K) (V, *time.Duration, error)
+
+// GenericSerializeFunc transforms a value before it is stored.
+type GenericSerializeFunc[K comparable, V any] func(key K, value V) (V, error)
+
+// GenericDeserializeFunc transforms a stored value before it is returned.
+type GenericDeserializeFunc[K comparable, V any] func(key K, value V) (V, error)
+
+// GenericEvictedFunc is called after an item is evicted from the cache.
+type GenericEvictedFunc[K comparable, V any] func(key K, value V)
+
+// GenericAddedFunc is called after an item is added to or updated in the cache.
+type GenericAddedFunc[K comparable, V any] func(key K, value V)
+
+// GenericPurgeVisitorFunc is called once per item as Purge tears the cache down.
+type GenericPurgeVisitorFunc[K comparable, V any] func(key K, value V)
+
+// GenericConfig configures a generics-based cache instance. It mirrors
+// Config's common fields, with loader/serializer/callback hooks typed to
+// (K, V) instead of interface{}. It has no MaxCost, CostFunc or EvictType;
+// the 2Q ratios live in GenericTwoQueueConfig.
+type GenericConfig[K comparable, V any] struct {
+	Size             int
+	DefaultTTL       *time.Duration
+	LoaderFunc       GenericLoaderFunc[K, V]
+	EvictedFunc      GenericEvictedFunc[K, V]
+	AddedFunc        GenericAddedFunc[K, V]
+	DeletedFunc      GenericEvictedFunc[K, V]
+	PurgeVisitorFunc GenericPurgeVisitorFunc[K, V]
+	SerializeFunc    GenericSerializeFunc[K, V]
+	DeserializeFunc  GenericDeserializeFunc[K, V]
+	Clock            Clock
+}
+
+// genericBaseCache holds the fields common to every generics-based cache
+// policy, analogous to the legacy baseCache but with typed hooks.
+type genericBaseCache[K comparable, V any] struct {
+	mu              sync.RWMutex
+	size            int
+	clock           Clock
+	defaultTTL      *time.Duration
+	loaderFunc      GenericLoaderFunc[K, V]
+	onAdd           GenericAddedFunc[K, V]
+	onEvict         GenericEvictedFunc[K, V]
+	onDel           GenericEvictedFunc[K, V]
+	onPurge         GenericPurgeVisitorFunc[K, V]
+	serializeWith   GenericSerializeFunc[K, V]
+	deserializeWith GenericDeserializeFunc[K, V]
+	stats           genericStats
+}
+
+func buildGenericCache[K comparable, V any](c *genericBaseCache[K, V], config GenericConfig[K, V]) {
+	size := config.Size
+	if size <= 0 {
+		size = 1
+	}
+	c.size = size
+	c.clock = config.Clock
+	if c.clock == nil {
+		c.clock = realClock{}
+	}
+	c.defaultTTL = config.DefaultTTL
+	c.loaderFunc = config.LoaderFunc
+	c.onAdd = config.AddedFunc
+	c.onEvict = config.EvictedFunc
+	c.onDel = config.DeletedFunc
+	c.onPurge = config.PurgeVisitorFunc
+	c.serializeWith = config.SerializeFunc
+	c.deserializeWith = config.DeserializeFunc
+}
+
+// HitCount returns the number of cache hits recorded so far.
+func (c *genericBaseCache[K, V]) HitCount() uint64 { return c.stats.HitCount() }
+
+// MissCount returns the number of cache misses recorded so far.
+func (c *genericBaseCache[K, V]) MissCount() uint64 { return c.stats.MissCount() }
+
+// LookupCount returns the total number of Get/GetIFPresent lookups.
+func (c *genericBaseCache[K, V]) LookupCount() uint64 {
+	return c.stats.HitCount() + c.stats.MissCount()
+}
+
+// HitRate returns the ratio of hits to lookups, or 0 if there have been none.
+func (c *genericBaseCache[K, V]) HitRate() float64 { return c.stats.HitRate() }
+
+// genericStats tracks hit/miss counts with atomics so readers don't need
+// to hold the cache's main mutex, mirroring how the legacy stats type is
+// updated outside of getValue's critical section.
+type genericStats struct {
+	hitCount  uint64
+	missCount uint64
+}
+
+func (s *genericStats) IncrHitCount() uint64  { return atomic.AddUint64(&s.hitCount, 1) }
+func (s *genericStats) IncrMissCount() uint64 { return atomic.AddUint64(&s.missCount, 1) }
+func (s *genericStats) HitCount() uint64      { return atomic.LoadUint64(&s.hitCount) }
+func (s *genericStats) MissCount() uint64     { return atomic.LoadUint64(&s.missCount) }
+
+func (s *genericStats) HitRate() float64 {
+	hc, mc := s.HitCount(), s.MissCount()
+	total := hc + mc
+	if total == 0 {
+		return 0.0
+	}
+	return float64(hc) / float64(total)
+}
@@ -0,0 +1,411 @@
+package gcache
+
+import (
+	"container/list"
+	"time"
+)
+
+// ARC is the generics-based counterpart of ARCCache: an Adaptive
+// Replacement Cache with typed items, avoiding interface{} boxing on
+// Get/Set.
+type ARC[K comparable, V any] struct {
+	genericBaseCache[K, V]
+	items map[K]*arcGenericItem[K, V]
+
+	part int
+	t1   *list.List
+	b1   *list.List
+	t2   *list.List
+	b2   *list.List
+
+	// b1Index and b2Index back b1/b2 with O(1) membership lookups,
+	// keyed the same way as items, instead of scanning the ghost lists.
+	b1Index map[K]*list.Element
+	b2Index map[K]*list.Element
+}
+
+// NewGenericARC returns a new generics-based ARC cache instance.
+func NewGenericARC[K comparable, V any](config GenericConfig[K, V]) *ARC[K, V] {
+	c := &ARC[K, V]{}
+	buildGenericCache(&c.genericBaseCache, config)
+	c.init()
+	return c
+}
+
+func (c *ARC[K, V]) init() {
+	c.part = 0
+	c.items = make(map[K]*arcGenericItem[K, V], c.size+1)
+	c.t1 = list.New()
+	c.b1 = list.New()
+	c.t2 = list.New()
+	c.b2 = list.New()
+	c.b1Index = make(map[K]*list.Element, c.size+1)
+	c.b2Index = make(map[K]*list.Element, c.size+1)
+}
+
+// Set a new key-value pair
+func (c *ARC[K, V]) Set(key K, value V) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.set(key, value)
+	return err
+}
+
+// SetWithTTL sets a new key-value pair with an expiration time
+func (c *ARC[K, V]) SetWithTTL(key K, value V, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, err := c.set(key, value)
+	if err != nil {
+		return err
+	}
+	t := c.clock.Now().Add(expiration)
+	item.expiration = &t
+	return nil
+}
+
+func (c *ARC[K, V]) set(key K, value V) (*arcGenericItem[K, V], error) {
+	var err error
+	if c.serializeWith != nil {
+		value, err = c.serializeWith(key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var item *arcGenericItem[K, V]
+	if existing, ok := c.items[key]; ok {
+		existing.value = value
+		c.access(existing)
+		item = existing
+	} else if el := c.lookupGhost(c.b1Index, key); el != nil {
+		b2Len := c.b2.Len()
+		if b2Len == 0 {
+			b2Len = 1
+		}
+		delta := c.b1.Len() / b2Len
+		if delta < 1 {
+			delta = 1
+		}
+		c.part += delta
+		if c.part > c.size {
+			c.part = c.size
+		}
+		c.b1.Remove(el)
+		delete(c.b1Index, key)
+		c.replace(false)
+		item = c.newItem(key, value)
+		item.element = c.t2.PushFront(item)
+		item.ls = c.t2
+		c.items[key] = item
+	} else if el := c.lookupGhost(c.b2Index, key); el != nil {
+		b1Len := c.b1.Len()
+		if b1Len == 0 {
+			b1Len = 1
+		}
+		delta := c.b2.Len() / b1Len
+		if delta < 1 {
+			delta = 1
+		}
+		c.part -= delta
+		if c.part < 0 {
+			c.part = 0
+		}
+		c.b2.Remove(el)
+		delete(c.b2Index, key)
+		c.replace(true)
+		item = c.newItem(key, value)
+		item.element = c.t2.PushFront(item)
+		item.ls = c.t2
+		c.items[key] = item
+	} else {
+		c.setNew(key, value)
+		item = c.items[key]
+	}
+
+	if c.defaultTTL != nil {
+		t := c.clock.Now().Add(*c.defaultTTL)
+		item.expiration = &t
+	}
+	if c.onAdd != nil {
+		c.onAdd(key, value)
+	}
+	return item, nil
+}
+
+func (c *ARC[K, V]) setNew(key K, value V) {
+	t1Len, b1Len := c.t1.Len(), c.b1.Len()
+	if t1Len+b1Len == c.size {
+		if t1Len < c.size {
+			c.removeGhostLRU(c.b1, c.b1Index)
+			c.replace(false)
+		} else {
+			c.evictLRU(c.t1)
+		}
+	} else if t1Len+b1Len < c.size {
+		total := t1Len + c.t2.Len() + b1Len + c.b2.Len()
+		if total >= c.size {
+			if total == 2*c.size {
+				c.removeGhostLRU(c.b2, c.b2Index)
+			}
+			c.replace(false)
+		}
+	}
+
+	item := c.newItem(key, value)
+	item.element = c.t1.PushFront(item)
+	item.ls = c.t1
+	c.items[key] = item
+}
+
+func (c *ARC[K, V]) replace(inB2 bool) {
+	if c.t1.Len() > 0 && (c.t1.Len() > c.part || (c.t1.Len() == c.part && inB2)) {
+		c.moveLRUToGhost(c.t1, c.b1, c.b1Index)
+	} else if c.t2.Len() > 0 {
+		c.moveLRUToGhost(c.t2, c.b2, c.b2Index)
+	} else if c.t1.Len() > 0 {
+		c.moveLRUToGhost(c.t1, c.b1, c.b1Index)
+	}
+}
+
+func (c *ARC[K, V]) moveLRUToGhost(src, ghost *list.List, ghostIndex map[K]*list.Element) {
+	el := src.Back()
+	if el == nil {
+		return
+	}
+	item := el.Value.(*arcGenericItem[K, V])
+	src.Remove(el)
+	delete(c.items, item.key)
+	ghostIndex[item.key] = ghost.PushFront(item.key)
+	for ghost.Len() > c.size {
+		c.removeGhostLRU(ghost, ghostIndex)
+	}
+	if c.onEvict != nil {
+		c.onEvict(item.key, item.value)
+	}
+}
+
+func (c *ARC[K, V]) evictLRU(src *list.List) {
+	el := src.Back()
+	if el == nil {
+		return
+	}
+	item := el.Value.(*arcGenericItem[K, V])
+	src.Remove(el)
+	delete(c.items, item.key)
+	if c.onEvict != nil {
+		c.onEvict(item.key, item.value)
+	}
+}
+
+func (c *ARC[K, V]) removeGhostLRU(ghost *list.List, ghostIndex map[K]*list.Element) {
+	el := ghost.Back()
+	if el == nil {
+		return
+	}
+	ghost.Remove(el)
+	delete(ghostIndex, el.Value.(K))
+}
+
+// lookupGhost reports whether key is present in a ghost list (b1 or b2)
+// via its backing index map, in O(1) rather than scanning the list.
+func (c *ARC[K, V]) lookupGhost(ghostIndex map[K]*list.Element, key K) *list.Element {
+	return ghostIndex[key]
+}
+
+func (c *ARC[K, V]) newItem(key K, value V) *arcGenericItem[K, V] {
+	return &arcGenericItem[K, V]{clock: c.clock, key: key, value: value}
+}
+
+func (c *ARC[K, V]) access(item *arcGenericItem[K, V]) {
+	item.ls.Remove(item.element)
+	item.element = c.t2.PushFront(item)
+	item.ls = c.t2
+}
+
+// Get returns a value from the cache pool using key if it exists.
+func (c *ARC[K, V]) Get(key K) (V, error) {
+	v, err := c.get(key, false)
+	if err == ErrKeyNotFound {
+		return c.getWithLoader(key)
+	}
+	return v, err
+}
+
+// GetIFPresent returns a value from the cache pool using key if it exists.
+func (c *ARC[K, V]) GetIFPresent(key K) (V, error) {
+	return c.get(key, false)
+}
+
+func (c *ARC[K, V]) get(key K, onLoad bool) (V, error) {
+	v, err := c.getValue(key, onLoad)
+	var zero V
+	if err != nil {
+		return zero, err
+	}
+	if c.deserializeWith != nil {
+		return c.deserializeWith(key, v)
+	}
+	return v, nil
+}
+
+func (c *ARC[K, V]) getValue(key K, onLoad bool) (V, error) {
+	c.mu.Lock()
+	item, ok := c.items[key]
+	if ok {
+		if !item.IsExpired(nil) {
+			c.access(item)
+			v := item.value
+			c.mu.Unlock()
+			if !onLoad {
+				c.stats.IncrHitCount()
+			}
+			return v, nil
+		}
+		c.removeItem(item)
+	}
+	c.mu.Unlock()
+	var zero V
+	if !onLoad {
+		c.stats.IncrMissCount()
+	}
+	return zero, ErrKeyNotFound
+}
+
+func (c *ARC[K, V]) getWithLoader(key K) (V, error) {
+	var zero V
+	if c.loaderFunc == nil {
+		return zero, ErrKeyNotFound
+	}
+	v, expiration, err := c.loaderFunc(key)
+	if err != nil {
+		return zero, err
+	}
+	c.mu.Lock()
+	item, err := c.set(key, v)
+	if err != nil {
+		c.mu.Unlock()
+		return zero, err
+	}
+	if expiration != nil {
+		t := c.clock.Now().Add(*expiration)
+		item.expiration = &t
+	}
+	c.mu.Unlock()
+	return v, nil
+}
+
+// Has checks if key exists in cache
+func (c *ARC[K, V]) Has(key K) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	now := time.Now()
+	return c.has(key, &now)
+}
+
+func (c *ARC[K, V]) has(key K, now *time.Time) bool {
+	item, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	return !item.IsExpired(now)
+}
+
+// Remove removes the provided key from the cache.
+func (c *ARC[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if item, ok := c.items[key]; ok {
+		c.removeItem(item)
+		if c.onDel != nil {
+			c.onDel(item.key, item.value)
+		}
+		return true
+	}
+	return false
+}
+
+func (c *ARC[K, V]) removeItem(item *arcGenericItem[K, V]) {
+	delete(c.items, item.key)
+	item.ls.Remove(item.element)
+}
+
+// GetALL returns all key-value pairs in the cache.
+func (c *ARC[K, V]) GetALL(checkExpired bool) map[K]V {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	items := make(map[K]V, len(c.items))
+	now := time.Now()
+	for k, item := range c.items {
+		if !checkExpired || c.has(k, &now) {
+			items[k] = item.value
+		}
+	}
+	return items
+}
+
+// Keys returns a slice of the keys in the cache
+func (c *ARC[K, V]) Keys(checkExpired bool) []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys := make([]K, 0, len(c.items))
+	now := time.Now()
+	for k := range c.items {
+		if !checkExpired || c.has(k, &now) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Len returns the number of items in the cache
+func (c *ARC[K, V]) Len(checkExpired bool) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !checkExpired {
+		return len(c.items)
+	}
+	var length int
+	now := time.Now()
+	for k := range c.items {
+		if c.has(k, &now) {
+			length++
+		}
+	}
+	return length
+}
+
+// Purge completely clears the cache
+func (c *ARC[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.onPurge != nil {
+		for key, item := range c.items {
+			c.onPurge(key, item.value)
+		}
+	}
+
+	c.init()
+}
+
+type arcGenericItem[K comparable, V any] struct {
+	clock      Clock
+	key        K
+	value      V
+	ls         *list.List
+	element    *list.Element
+	expiration *time.Time
+}
+
+// IsExpired returns boolean value whether this item is expired or not
+func (it *arcGenericItem[K, V]) IsExpired(now *time.Time) bool {
+	if it.expiration == nil {
+		return false
+	}
+	if now == nil {
+		t := it.clock.Now()
+		now = &t
+	}
+	return it.expiration.Before(*now)
+}
@@ -0,0 +1,369 @@
+package gcache
+
+import (
+	"container/list"
+	"time"
+)
+
+// LFU is the generics-based counterpart of LFUCache: it discards the
+// least frequently used items first, but keeps items and callback hooks
+// typed to (K, V) to avoid interface{} boxing on the hot path.
+type LFU[K comparable, V any] struct {
+	genericBaseCache[K, V]
+	items    map[K]*lfuGenericItem[K, V]
+	freqList *list.List // list for genericFreqEntry[K,V]
+}
+
+// NewGenericLFU returns a new generics-based LFU cache instance.
+func NewGenericLFU[K comparable, V any](config GenericConfig[K, V]) *LFU[K, V] {
+	c := &LFU[K, V]{}
+	buildGenericCache(&c.genericBaseCache, config)
+	c.init()
+	return c
+}
+
+func (c *LFU[K, V]) init() {
+	c.freqList = list.New()
+	c.items = make(map[K]*lfuGenericItem[K, V], c.size+1)
+	c.freqList.PushFront(&genericFreqEntry[K, V]{
+		freq:  0,
+		items: make(map[*lfuGenericItem[K, V]]struct{}),
+	})
+}
+
+// Set a new key-value pair
+func (c *LFU[K, V]) Set(key K, value V) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.set(key, value)
+	return err
+}
+
+// SetWithTTL sets a new key-value pair with an expiration time
+func (c *LFU[K, V]) SetWithTTL(key K, value V, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, err := c.set(key, value)
+	if err != nil {
+		return err
+	}
+	t := c.clock.Now().Add(expiration)
+	item.expiration = &t
+	return nil
+}
+
+func (c *LFU[K, V]) set(key K, value V) (*lfuGenericItem[K, V], error) {
+	var err error
+	if c.serializeWith != nil {
+		value, err = c.serializeWith(key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item, ok := c.items[key]
+	if ok {
+		item.value = value
+	} else {
+		if len(c.items) >= c.size {
+			c.evict(1)
+		}
+		item = &lfuGenericItem[K, V]{
+			clock: c.clock,
+			key:   key,
+			value: value,
+		}
+		el := c.freqList.Front()
+		fe := el.Value.(*genericFreqEntry[K, V])
+		fe.items[item] = struct{}{}
+
+		item.freqElement = el
+		c.items[key] = item
+	}
+
+	if c.defaultTTL != nil {
+		t := c.clock.Now().Add(*c.defaultTTL)
+		item.expiration = &t
+	}
+
+	if c.onAdd != nil {
+		c.onAdd(key, value)
+	}
+
+	return item, nil
+}
+
+// Get returns a value from the cache pool using key if it exists.
+// If it does not exist and a LoaderFunc is configured, it generates
+// the value using LoaderFunc and returns it.
+func (c *LFU[K, V]) Get(key K) (V, error) {
+	v, err := c.get(key, false)
+	if err == ErrKeyNotFound {
+		return c.getWithLoader(key)
+	}
+	return v, err
+}
+
+// GetIFPresent returns a value from the cache pool using key if it exists.
+// If it does not exist, it returns ErrKeyNotFound.
+func (c *LFU[K, V]) GetIFPresent(key K) (V, error) {
+	return c.get(key, false)
+}
+
+func (c *LFU[K, V]) get(key K, onLoad bool) (V, error) {
+	v, err := c.getValue(key, onLoad)
+	var zero V
+	if err != nil {
+		return zero, err
+	}
+	if c.deserializeWith != nil {
+		return c.deserializeWith(key, v)
+	}
+	return v, nil
+}
+
+func (c *LFU[K, V]) getValue(key K, onLoad bool) (V, error) {
+	c.mu.Lock()
+	item, ok := c.items[key]
+	if ok {
+		if !item.IsExpired(nil) {
+			c.increment(item)
+			v := item.value
+			c.mu.Unlock()
+			if !onLoad {
+				c.stats.IncrHitCount()
+			}
+			return v, nil
+		}
+		c.removeItem(item)
+	}
+	c.mu.Unlock()
+	var zero V
+	if !onLoad {
+		c.stats.IncrMissCount()
+	}
+	return zero, ErrKeyNotFound
+}
+
+func (c *LFU[K, V]) getWithLoader(key K) (V, error) {
+	var zero V
+	if c.loaderFunc == nil {
+		return zero, ErrKeyNotFound
+	}
+	v, expiration, err := c.loaderFunc(key)
+	if err != nil {
+		return zero, err
+	}
+	c.mu.Lock()
+	item, err := c.set(key, v)
+	if err != nil {
+		c.mu.Unlock()
+		return zero, err
+	}
+	if expiration != nil {
+		t := c.clock.Now().Add(*expiration)
+		item.expiration = &t
+	}
+	c.mu.Unlock()
+	return v, nil
+}
+
+func (c *LFU[K, V]) increment(item *lfuGenericItem[K, V]) {
+	currentFreqElement := item.freqElement
+	currentFreqEntry := currentFreqElement.Value.(*genericFreqEntry[K, V])
+	nextFreq := currentFreqEntry.freq + 1
+	delete(currentFreqEntry.items, item)
+
+	nextFreqElement := currentFreqElement.Next()
+	if nextFreqElement == nil || nextFreqElement.Value.(*genericFreqEntry[K, V]).freq != nextFreq {
+		nextFreqElement = c.freqList.InsertAfter(&genericFreqEntry[K, V]{
+			freq:  nextFreq,
+			items: make(map[*lfuGenericItem[K, V]]struct{}),
+		}, currentFreqElement)
+	}
+	nextFreqElement.Value.(*genericFreqEntry[K, V]).items[item] = struct{}{}
+	item.freqElement = nextFreqElement
+
+	c.compact(currentFreqElement, currentFreqEntry)
+}
+
+func (c *LFU[K, V]) compact(el *list.Element, fe *genericFreqEntry[K, V]) {
+	if fe.freq != 0 && len(fe.items) == 0 {
+		c.freqList.Remove(el)
+	}
+}
+
+// evict removes the count least frequent items from the cache.
+func (c *LFU[K, V]) evict(count int) {
+	for i := 0; i < count; i++ {
+		victim := c.leastFrequent()
+		if victim == nil {
+			return
+		}
+		c.removeItem(victim)
+		if c.onEvict != nil {
+			c.onEvict(victim.key, victim.value)
+		}
+	}
+}
+
+func (c *LFU[K, V]) leastFrequent() *lfuGenericItem[K, V] {
+	for entry := c.freqList.Front(); entry != nil; entry = entry.Next() {
+		for item := range entry.Value.(*genericFreqEntry[K, V]).items {
+			return item
+		}
+	}
+	return nil
+}
+
+// Frequency returns how many times key has been accessed via Get since it
+// was added, and whether key is present in the cache at all.
+func (c *LFU[K, V]) Frequency(key K) (uint, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	item, ok := c.items[key]
+	if !ok {
+		return 0, false
+	}
+	return item.freqElement.Value.(*genericFreqEntry[K, V]).freq, true
+}
+
+// TopK returns up to k of the cache's current keys ordered from
+// most-frequent to least-frequent.
+func (c *LFU[K, V]) TopK(k int) []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if k <= 0 {
+		return nil
+	}
+	keys := make([]K, 0, k)
+	for entry := c.freqList.Back(); entry != nil; entry = entry.Prev() {
+		for item := range entry.Value.(*genericFreqEntry[K, V]).items {
+			if len(keys) >= k {
+				return keys
+			}
+			keys = append(keys, item.key)
+		}
+	}
+	return keys
+}
+
+// Has checks if key exists in cache
+func (c *LFU[K, V]) Has(key K) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	now := time.Now()
+	return c.has(key, &now)
+}
+
+func (c *LFU[K, V]) has(key K, now *time.Time) bool {
+	item, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	return !item.IsExpired(now)
+}
+
+// Remove removes the provided key from the cache.
+func (c *LFU[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if item, ok := c.items[key]; ok {
+		c.removeItem(item)
+		if c.onDel != nil {
+			c.onDel(item.key, item.value)
+		}
+		return true
+	}
+	return false
+}
+
+func (c *LFU[K, V]) removeItem(item *lfuGenericItem[K, V]) {
+	delete(c.items, item.key)
+	delete(item.freqElement.Value.(*genericFreqEntry[K, V]).items, item)
+	c.compact(item.freqElement, item.freqElement.Value.(*genericFreqEntry[K, V]))
+}
+
+// GetALL returns all key-value pairs in the cache.
+func (c *LFU[K, V]) GetALL(checkExpired bool) map[K]V {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	items := make(map[K]V, len(c.items))
+	now := time.Now()
+	for k, item := range c.items {
+		if !checkExpired || c.has(k, &now) {
+			items[k] = item.value
+		}
+	}
+	return items
+}
+
+// Keys returns a slice of the keys in the cache
+func (c *LFU[K, V]) Keys(checkExpired bool) []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys := make([]K, 0, len(c.items))
+	now := time.Now()
+	for k := range c.items {
+		if !checkExpired || c.has(k, &now) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Len returns the number of items in the cache
+func (c *LFU[K, V]) Len(checkExpired bool) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !checkExpired {
+		return len(c.items)
+	}
+	var length int
+	now := time.Now()
+	for k := range c.items {
+		if c.has(k, &now) {
+			length++
+		}
+	}
+	return length
+}
+
+// Purge completely clears the cache
+func (c *LFU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.onPurge != nil {
+		for key, item := range c.items {
+			c.onPurge(key, item.value)
+		}
+	}
+
+	c.init()
+}
+
+type genericFreqEntry[K comparable, V any] struct {
+	freq  uint
+	items map[*lfuGenericItem[K, V]]struct{}
+}
+
+type lfuGenericItem[K comparable, V any] struct {
+	clock       Clock
+	key         K
+	value       V
+	freqElement *list.Element
+	expiration  *time.Time
+}
+
+// IsExpired returns boolean value whether this item is expired or not
+func (it *lfuGenericItem[K, V]) IsExpired(now *time.Time) bool {
+	if it.expiration == nil {
+		return false
+	}
+	if now == nil {
+		t := it.clock.Now()
+		now = &t
+	}
+	return it.expiration.Before(*now)
+}
@@ -0,0 +1,299 @@
+package gcache
+
+import (
+	"container/list"
+	"time"
+)
+
+// Sieve is the generics-based counterpart of SieveCache: the SIEVE
+// eviction policy with typed items, avoiding interface{} boxing on
+// Get/Set.
+type Sieve[K comparable, V any] struct {
+	genericBaseCache[K, V]
+	items map[K]*sieveGenericItem[K, V]
+	ll    *list.List
+	hand  *list.Element
+}
+
+// NewGenericSieve returns a new generics-based SIEVE cache instance.
+func NewGenericSieve[K comparable, V any](config GenericConfig[K, V]) *Sieve[K, V] {
+	c := &Sieve[K, V]{}
+	buildGenericCache(&c.genericBaseCache, config)
+	c.init()
+	return c
+}
+
+func (c *Sieve[K, V]) init() {
+	c.items = make(map[K]*sieveGenericItem[K, V], c.size+1)
+	c.ll = list.New()
+	c.hand = nil
+}
+
+// Set a new key-value pair
+func (c *Sieve[K, V]) Set(key K, value V) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.set(key, value)
+	return err
+}
+
+// SetWithTTL sets a new key-value pair with an expiration time
+func (c *Sieve[K, V]) SetWithTTL(key K, value V, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, err := c.set(key, value)
+	if err != nil {
+		return err
+	}
+	t := c.clock.Now().Add(expiration)
+	item.expiration = &t
+	return nil
+}
+
+func (c *Sieve[K, V]) set(key K, value V) (*sieveGenericItem[K, V], error) {
+	var err error
+	if c.serializeWith != nil {
+		value, err = c.serializeWith(key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item, ok := c.items[key]
+	if ok {
+		item.value = value
+	} else {
+		if len(c.items) >= c.size {
+			c.evict()
+		}
+		item = &sieveGenericItem[K, V]{clock: c.clock, key: key, value: value}
+		item.element = c.ll.PushFront(item)
+		c.items[key] = item
+	}
+
+	if c.defaultTTL != nil {
+		t := c.clock.Now().Add(*c.defaultTTL)
+		item.expiration = &t
+	}
+	if c.onAdd != nil {
+		c.onAdd(key, value)
+	}
+	return item, nil
+}
+
+// Get returns a value from the cache pool using key if it exists.
+func (c *Sieve[K, V]) Get(key K) (V, error) {
+	v, err := c.get(key, false)
+	if err == ErrKeyNotFound {
+		return c.getWithLoader(key)
+	}
+	return v, err
+}
+
+// GetIFPresent returns a value from the cache pool using key if it exists.
+func (c *Sieve[K, V]) GetIFPresent(key K) (V, error) {
+	return c.get(key, false)
+}
+
+func (c *Sieve[K, V]) get(key K, onLoad bool) (V, error) {
+	v, err := c.getValue(key, onLoad)
+	var zero V
+	if err != nil {
+		return zero, err
+	}
+	if c.deserializeWith != nil {
+		return c.deserializeWith(key, v)
+	}
+	return v, nil
+}
+
+func (c *Sieve[K, V]) getValue(key K, onLoad bool) (V, error) {
+	c.mu.Lock()
+	item, ok := c.items[key]
+	if ok {
+		if !item.IsExpired(nil) {
+			item.visited = true
+			v := item.value
+			c.mu.Unlock()
+			if !onLoad {
+				c.stats.IncrHitCount()
+			}
+			return v, nil
+		}
+		c.removeItem(item)
+	}
+	c.mu.Unlock()
+	var zero V
+	if !onLoad {
+		c.stats.IncrMissCount()
+	}
+	return zero, ErrKeyNotFound
+}
+
+func (c *Sieve[K, V]) getWithLoader(key K) (V, error) {
+	var zero V
+	if c.loaderFunc == nil {
+		return zero, ErrKeyNotFound
+	}
+	v, expiration, err := c.loaderFunc(key)
+	if err != nil {
+		return zero, err
+	}
+	c.mu.Lock()
+	item, err := c.set(key, v)
+	if err != nil {
+		c.mu.Unlock()
+		return zero, err
+	}
+	if expiration != nil {
+		t := c.clock.Now().Add(*expiration)
+		item.expiration = &t
+	}
+	c.mu.Unlock()
+	return v, nil
+}
+
+// evict walks the list from the hand towards the head, clearing visited
+// bits, until it finds an unvisited item to evict.
+func (c *Sieve[K, V]) evict() {
+	el := c.hand
+	if el == nil {
+		el = c.ll.Back()
+	}
+	for el != nil {
+		item := el.Value.(*sieveGenericItem[K, V])
+		if item.visited {
+			item.visited = false
+			el = el.Prev()
+			if el == nil {
+				el = c.ll.Back()
+			}
+			continue
+		}
+		c.hand = el.Prev()
+		c.ll.Remove(el)
+		delete(c.items, item.key)
+		if c.onEvict != nil {
+			c.onEvict(item.key, item.value)
+		}
+		return
+	}
+}
+
+// Has checks if key exists in cache
+func (c *Sieve[K, V]) Has(key K) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	now := time.Now()
+	return c.has(key, &now)
+}
+
+func (c *Sieve[K, V]) has(key K, now *time.Time) bool {
+	item, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	return !item.IsExpired(now)
+}
+
+// Remove removes the provided key from the cache.
+func (c *Sieve[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if item, ok := c.items[key]; ok {
+		c.removeItem(item)
+		if c.onDel != nil {
+			c.onDel(item.key, item.value)
+		}
+		return true
+	}
+	return false
+}
+
+func (c *Sieve[K, V]) removeItem(item *sieveGenericItem[K, V]) {
+	if c.hand == item.element {
+		c.hand = item.element.Prev()
+	}
+	delete(c.items, item.key)
+	c.ll.Remove(item.element)
+}
+
+// GetALL returns all key-value pairs in the cache.
+func (c *Sieve[K, V]) GetALL(checkExpired bool) map[K]V {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	items := make(map[K]V, len(c.items))
+	now := time.Now()
+	for k, item := range c.items {
+		if !checkExpired || c.has(k, &now) {
+			items[k] = item.value
+		}
+	}
+	return items
+}
+
+// Keys returns a slice of the keys in the cache
+func (c *Sieve[K, V]) Keys(checkExpired bool) []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys := make([]K, 0, len(c.items))
+	now := time.Now()
+	for k := range c.items {
+		if !checkExpired || c.has(k, &now) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Len returns the number of items in the cache
+func (c *Sieve[K, V]) Len(checkExpired bool) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !checkExpired {
+		return len(c.items)
+	}
+	var length int
+	now := time.Now()
+	for k := range c.items {
+		if c.has(k, &now) {
+			length++
+		}
+	}
+	return length
+}
+
+// Purge completely clears the cache
+func (c *Sieve[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.onPurge != nil {
+		for key, item := range c.items {
+			c.onPurge(key, item.value)
+		}
+	}
+
+	c.init()
+}
+
+type sieveGenericItem[K comparable, V any] struct {
+	clock      Clock
+	key        K
+	value      V
+	visited    bool
+	element    *list.Element
+	expiration *time.Time
+}
+
+// IsExpired returns boolean value whether this item is expired or not
+func (it *sieveGenericItem[K, V]) IsExpired(now *time.Time) bool {
+	if it.expiration == nil {
+		return false
+	}
+	if now == nil {
+		t := it.clock.Now()
+		now = &t
+	}
+	return it.expiration.Before(*now)
+}
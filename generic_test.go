@@ -0,0 +1,105 @@
+package gcache
+
+import "testing"
+
+func TestGenericLFUBasicGetSetAndEviction(t *testing.T) {
+	c := NewGenericLFU(GenericConfig[string, int]{Size: 2})
+	_ = c.Set("a", 1)
+	_ = c.Set("b", 2)
+	v, err := c.getValue("a", false)
+	if err != nil || v != 1 {
+		t.Fatalf("getValue(a) = %v, %v; want 1, nil", v, err)
+	}
+	_ = c.Set("c", 3) // "b" is least frequent, gets evicted
+	if _, err := c.getValue("b", false); err != ErrKeyNotFound {
+		t.Fatalf("getValue(b) err = %v; want ErrKeyNotFound", err)
+	}
+	if c.Len(false) != 2 {
+		t.Fatalf("Len = %d; want 2", c.Len(false))
+	}
+}
+
+func TestGenericARCGhostHitAdaptsPart(t *testing.T) {
+	c := NewGenericARC(GenericConfig[string, string]{Size: 4})
+	for _, k := range []string{"a", "b", "c", "d"} {
+		_ = c.Set(k, k)
+	}
+	if _, err := c.getValue("a", false); err != nil {
+		t.Fatalf("getValue(a): %v", err)
+	}
+	_ = c.Set("e", "e")
+
+	if c.b1.Len() == 0 {
+		t.Fatalf("expected an entry in the b1 ghost list")
+	}
+	partBefore := c.part
+	ghostKey := c.b1.Back().Value.(string)
+	_ = c.Set(ghostKey, "touched")
+	if c.part <= partBefore {
+		t.Fatalf("part did not grow on b1 ghost hit: before=%d after=%d", partBefore, c.part)
+	}
+}
+
+func TestGenericTwoQueueSetPromotesRecentToFrequent(t *testing.T) {
+	c := NewGenericTwoQueue(GenericTwoQueueConfig[string, int]{
+		GenericConfig: GenericConfig[string, int]{Size: 4},
+	})
+	_ = c.Set("a", 1)
+	item := c.items["a"]
+	if item.ls != c.recent {
+		t.Fatalf("newly-set key should start in recent")
+	}
+	_ = c.Set("a", 2)
+	if item.ls != c.frequent {
+		t.Fatalf("a second Set should promote the key into frequent")
+	}
+}
+
+func TestGenericSieveBasicGetSetAndEviction(t *testing.T) {
+	c := NewGenericSieve(GenericConfig[string, int]{Size: 2})
+	_ = c.Set("a", 1)
+	_ = c.Set("b", 2)
+	_, _ = c.getValue("a", false) // mark "a" visited
+	_ = c.Set("c", 3)             // "b" is unvisited, gets evicted
+
+	if _, err := c.getValue("b", false); err != ErrKeyNotFound {
+		t.Fatalf("getValue(b) err = %v; want ErrKeyNotFound", err)
+	}
+	if _, err := c.getValue("a", false); err != nil {
+		t.Fatalf("getValue(a): %v", err)
+	}
+}
+
+func TestGenericStatsAndDeletedFunc(t *testing.T) {
+	var evicted, deleted []string
+	c := NewGenericLFU(GenericConfig[string, int]{
+		Size:        2,
+		EvictedFunc: func(key string, value int) { evicted = append(evicted, key) },
+		DeletedFunc: func(key string, value int) { deleted = append(deleted, key) },
+	})
+	_ = c.Set("a", 1)
+	_ = c.Set("b", 2)
+	_, _ = c.getValue("a", false)
+	_, _ = c.getValue("missing", false)
+
+	if got := c.HitCount(); got != 1 {
+		t.Fatalf("HitCount() = %d; want 1", got)
+	}
+	if got := c.MissCount(); got != 1 {
+		t.Fatalf("MissCount() = %d; want 1", got)
+	}
+	if got := c.LookupCount(); got != 2 {
+		t.Fatalf("LookupCount() = %d; want 2", got)
+	}
+	if got := c.HitRate(); got != 0.5 {
+		t.Fatalf("HitRate() = %v; want 0.5", got)
+	}
+
+	c.Remove("a")
+	if len(deleted) != 1 || deleted[0] != "a" {
+		t.Fatalf("deleted = %v; want [a]", deleted)
+	}
+	if len(evicted) != 0 {
+		t.Fatalf("evicted = %v; want none (Remove should not fire EvictedFunc)", evicted)
+	}
+}
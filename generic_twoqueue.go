@@ -0,0 +1,383 @@
+package gcache
+
+import (
+	"container/list"
+	"time"
+)
+
+// TwoQueue is the generics-based counterpart of TwoQueueCache: a 2Q
+// eviction policy with typed items, avoiding interface{} boxing on
+// Get/Set.
+type TwoQueue[K comparable, V any] struct {
+	genericBaseCache[K, V]
+	items map[K]*twoQueueGenericItem[K, V]
+
+	recentRatio float64
+	ghostRatio  float64
+	recentSize  int
+
+	recent      *list.List
+	frequent    *list.List
+	recentEvict *list.List
+
+	// recentEvictIndex backs recentEvict with an O(1) membership lookup,
+	// instead of scanning the ghost list.
+	recentEvictIndex map[K]*list.Element
+}
+
+// GenericTwoQueueConfig extends GenericConfig with 2Q-specific tuning.
+type GenericTwoQueueConfig[K comparable, V any] struct {
+	GenericConfig[K, V]
+	RecentRatio float64
+	GhostRatio  float64
+}
+
+// NewGenericTwoQueue returns a new generics-based 2Q cache instance.
+func NewGenericTwoQueue[K comparable, V any](config GenericTwoQueueConfig[K, V]) *TwoQueue[K, V] {
+	c := &TwoQueue[K, V]{}
+	buildGenericCache(&c.genericBaseCache, config.GenericConfig)
+
+	c.recentRatio = config.RecentRatio
+	if c.recentRatio <= 0 {
+		c.recentRatio = TwoQueueRecentRatio
+	}
+	c.ghostRatio = config.GhostRatio
+	if c.ghostRatio <= 0 {
+		c.ghostRatio = TwoQueueGhostRatio
+	}
+
+	c.init()
+	return c
+}
+
+func (c *TwoQueue[K, V]) init() {
+	c.recentSize = int(float64(c.size) * c.recentRatio)
+	c.items = make(map[K]*twoQueueGenericItem[K, V], c.size+1)
+	c.recent = list.New()
+	c.frequent = list.New()
+	c.recentEvict = list.New()
+	c.recentEvictIndex = make(map[K]*list.Element, c.size+1)
+}
+
+// Set a new key-value pair
+func (c *TwoQueue[K, V]) Set(key K, value V) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.set(key, value)
+	return err
+}
+
+// SetWithTTL sets a new key-value pair with an expiration time
+func (c *TwoQueue[K, V]) SetWithTTL(key K, value V, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, err := c.set(key, value)
+	if err != nil {
+		return err
+	}
+	t := c.clock.Now().Add(expiration)
+	item.expiration = &t
+	return nil
+}
+
+func (c *TwoQueue[K, V]) set(key K, value V) (*twoQueueGenericItem[K, V], error) {
+	var err error
+	if c.serializeWith != nil {
+		value, err = c.serializeWith(key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if item, ok := c.items[key]; ok {
+		item.value = value
+		c.promote(item)
+		return c.finishSet(key, item), nil
+	}
+
+	if el := c.lookupGhost(c.recentEvictIndex, key); el != nil {
+		c.recentEvict.Remove(el)
+		delete(c.recentEvictIndex, key)
+		c.ensureSpace()
+		item := c.newItem(key, value)
+		item.element = c.frequent.PushFront(item)
+		item.ls = c.frequent
+		c.items[key] = item
+		return c.finishSet(key, item), nil
+	}
+
+	c.ensureSpace()
+	item := c.newItem(key, value)
+	item.element = c.recent.PushFront(item)
+	item.ls = c.recent
+	c.items[key] = item
+	return c.finishSet(key, item), nil
+}
+
+func (c *TwoQueue[K, V]) finishSet(key K, item *twoQueueGenericItem[K, V]) *twoQueueGenericItem[K, V] {
+	if c.defaultTTL != nil {
+		t := c.clock.Now().Add(*c.defaultTTL)
+		item.expiration = &t
+	}
+	if c.onAdd != nil {
+		c.onAdd(key, item.value)
+	}
+	return item
+}
+
+func (c *TwoQueue[K, V]) ensureSpace() {
+	if c.recent.Len()+c.frequent.Len() < c.size {
+		return
+	}
+	if c.recent.Len() > 0 && c.recent.Len() >= c.recentSize {
+		c.evictRecentToGhost()
+		return
+	}
+	if c.frequent.Len() > 0 {
+		c.evictFrequent()
+		return
+	}
+	c.evictRecentToGhost()
+}
+
+func (c *TwoQueue[K, V]) evictRecentToGhost() {
+	el := c.recent.Back()
+	if el == nil {
+		return
+	}
+	item := el.Value.(*twoQueueGenericItem[K, V])
+	c.recent.Remove(el)
+	delete(c.items, item.key)
+	c.recentEvictIndex[item.key] = c.recentEvict.PushFront(item.key)
+	for c.recentEvict.Len() > int(float64(c.size)*c.ghostRatio) {
+		back := c.recentEvict.Back()
+		c.recentEvict.Remove(back)
+		delete(c.recentEvictIndex, back.Value.(K))
+	}
+	if c.onEvict != nil {
+		c.onEvict(item.key, item.value)
+	}
+}
+
+func (c *TwoQueue[K, V]) evictFrequent() {
+	el := c.frequent.Back()
+	if el == nil {
+		return
+	}
+	item := el.Value.(*twoQueueGenericItem[K, V])
+	c.frequent.Remove(el)
+	delete(c.items, item.key)
+	if c.onEvict != nil {
+		c.onEvict(item.key, item.value)
+	}
+}
+
+// lookupGhost reports whether key is present in the recentEvict ghost
+// list via its backing index map, in O(1) rather than scanning the list.
+func (c *TwoQueue[K, V]) lookupGhost(ghostIndex map[K]*list.Element, key K) *list.Element {
+	return ghostIndex[key]
+}
+
+func (c *TwoQueue[K, V]) newItem(key K, value V) *twoQueueGenericItem[K, V] {
+	return &twoQueueGenericItem[K, V]{clock: c.clock, key: key, value: value}
+}
+
+// promote moves a one-time-seen entry from recent into frequent on its
+// second access, whether that access came via Get or Set; an entry
+// already in frequent just moves to the MRU position.
+func (c *TwoQueue[K, V]) promote(item *twoQueueGenericItem[K, V]) {
+	if item.ls == c.recent {
+		c.recent.Remove(item.element)
+		item.element = c.frequent.PushFront(item)
+		item.ls = c.frequent
+	} else {
+		c.frequent.MoveToFront(item.element)
+	}
+}
+
+// Get returns a value from the cache pool using key if it exists.
+func (c *TwoQueue[K, V]) Get(key K) (V, error) {
+	v, err := c.get(key, false)
+	if err == ErrKeyNotFound {
+		return c.getWithLoader(key)
+	}
+	return v, err
+}
+
+// GetIFPresent returns a value from the cache pool using key if it exists.
+func (c *TwoQueue[K, V]) GetIFPresent(key K) (V, error) {
+	return c.get(key, false)
+}
+
+func (c *TwoQueue[K, V]) get(key K, onLoad bool) (V, error) {
+	v, err := c.getValue(key, onLoad)
+	var zero V
+	if err != nil {
+		return zero, err
+	}
+	if c.deserializeWith != nil {
+		return c.deserializeWith(key, v)
+	}
+	return v, nil
+}
+
+func (c *TwoQueue[K, V]) getValue(key K, onLoad bool) (V, error) {
+	c.mu.Lock()
+	item, ok := c.items[key]
+	if ok {
+		if !item.IsExpired(nil) {
+			c.promote(item)
+			v := item.value
+			c.mu.Unlock()
+			if !onLoad {
+				c.stats.IncrHitCount()
+			}
+			return v, nil
+		}
+		c.removeItem(item)
+	}
+	c.mu.Unlock()
+	var zero V
+	if !onLoad {
+		c.stats.IncrMissCount()
+	}
+	return zero, ErrKeyNotFound
+}
+
+func (c *TwoQueue[K, V]) getWithLoader(key K) (V, error) {
+	var zero V
+	if c.loaderFunc == nil {
+		return zero, ErrKeyNotFound
+	}
+	v, expiration, err := c.loaderFunc(key)
+	if err != nil {
+		return zero, err
+	}
+	c.mu.Lock()
+	item, err := c.set(key, v)
+	if err != nil {
+		c.mu.Unlock()
+		return zero, err
+	}
+	if expiration != nil {
+		t := c.clock.Now().Add(*expiration)
+		item.expiration = &t
+	}
+	c.mu.Unlock()
+	return v, nil
+}
+
+// Has checks if key exists in cache
+func (c *TwoQueue[K, V]) Has(key K) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	now := time.Now()
+	return c.has(key, &now)
+}
+
+func (c *TwoQueue[K, V]) has(key K, now *time.Time) bool {
+	item, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	return !item.IsExpired(now)
+}
+
+// Remove removes the provided key from the cache.
+func (c *TwoQueue[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if item, ok := c.items[key]; ok {
+		c.removeItem(item)
+		if c.onDel != nil {
+			c.onDel(item.key, item.value)
+		}
+		return true
+	}
+	return false
+}
+
+func (c *TwoQueue[K, V]) removeItem(item *twoQueueGenericItem[K, V]) {
+	delete(c.items, item.key)
+	item.ls.Remove(item.element)
+}
+
+// GetALL returns all key-value pairs in the cache.
+func (c *TwoQueue[K, V]) GetALL(checkExpired bool) map[K]V {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	items := make(map[K]V, len(c.items))
+	now := time.Now()
+	for k, item := range c.items {
+		if !checkExpired || c.has(k, &now) {
+			items[k] = item.value
+		}
+	}
+	return items
+}
+
+// Keys returns a slice of the keys in the cache
+func (c *TwoQueue[K, V]) Keys(checkExpired bool) []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys := make([]K, 0, len(c.items))
+	now := time.Now()
+	for k := range c.items {
+		if !checkExpired || c.has(k, &now) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Len returns the number of items in the cache
+func (c *TwoQueue[K, V]) Len(checkExpired bool) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !checkExpired {
+		return len(c.items)
+	}
+	var length int
+	now := time.Now()
+	for k := range c.items {
+		if c.has(k, &now) {
+			length++
+		}
+	}
+	return length
+}
+
+// Purge completely clears the cache
+func (c *TwoQueue[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.onPurge != nil {
+		for key, item := range c.items {
+			c.onPurge(key, item.value)
+		}
+	}
+
+	c.init()
+}
+
+type twoQueueGenericItem[K comparable, V any] struct {
+	clock      Clock
+	key        K
+	value      V
+	ls         *list.List
+	element    *list.Element
+	expiration *time.Time
+}
+
+// IsExpired returns boolean value whether this item is expired or not
+func (it *twoQueueGenericItem[K, V]) IsExpired(now *time.Time) bool {
+	if it.expiration == nil {
+		return false
+	}
+	if now == nil {
+		t := it.clock.Now()
+		now = &t
+	}
+	return it.expiration.Before(*now)
+}
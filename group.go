@@ -0,0 +1,51 @@
+package gcache
+
+import "sync"
+
+// call represents an in-flight or completed invocation managed by Group.
+type call struct {
+	wg  sync.WaitGroup
+	val interface{}
+	err error
+}
+
+// Group de-duplicates concurrent loads for the same key, so that a
+// cache stampede (many goroutines missing the same key at once) only
+// invokes LoaderFunc once per key in flight.
+type Group struct {
+	mu    sync.Mutex
+	calls map[interface{}]*call
+	cache interface{}
+}
+
+// Do executes fn for key, or waits for and returns the result of an
+// already in-flight call for the same key. If isWait is false and a
+// call is already in flight, Do returns immediately without waiting.
+func (g *Group) Do(key interface{}, fn func() (interface{}, error), isWait bool) (v interface{}, called bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[interface{}]*call)
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		if !isWait {
+			return nil, false, nil
+		}
+		c.wg.Wait()
+		return c.val, false, c.err
+	}
+
+	c := new(call)
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.val, c.err = fn()
+	c.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return c.val, true, c.err
+}
@@ -2,14 +2,23 @@ package gcache
 
 import (
 	"container/list"
+	"errors"
 	"time"
 )
 
+// ErrItemTooLarge is returned by Set/SetWithTTL when a single item's cost,
+// as reported by Config.CostFunc, exceeds Config.MaxCost on its own and so
+// can never fit regardless of what else is evicted.
+var ErrItemTooLarge = errors.New("item cost exceeds MaxCost")
+
 // LFUCache represent cache which discards the least frequently used items first
 type LFUCache struct {
 	baseCache
-	items    map[interface{}]*lfuItem
-	freqList *list.List // list for freqEntry
+	items       map[interface{}]*lfuItem
+	freqList    *list.List // list for freqEntry
+	maxCost     int64
+	costFunc    func(key, value interface{}) int64
+	currentCost int64
 }
 
 // NewLFU returns new LFU cache instance
@@ -21,6 +30,12 @@ func newLFUCache(config Config) *LFUCache {
 	c := &LFUCache{}
 	buildCache(&c.baseCache, config)
 
+	c.maxCost = config.MaxCost
+	c.costFunc = config.CostFunc
+	if c.costFunc == nil {
+		c.costFunc = func(key, value interface{}) int64 { return 1 }
+	}
+
 	c.init()
 	c.loadGroup.cache = c
 	return c
@@ -29,6 +44,7 @@ func newLFUCache(config Config) *LFUCache {
 func (c *LFUCache) init() {
 	c.freqList = list.New()
 	c.items = make(map[interface{}]*lfuItem, c.size+1)
+	c.currentCost = 0
 	c.freqList.PushFront(&freqEntry{
 		freq:  0,
 		items: make(map[*lfuItem]struct{}),
@@ -66,19 +82,35 @@ func (c *LFUCache) set(key, value interface{}) (interface{}, error) {
 		}
 	}
 
+	cost := c.costFunc(key, value)
+	if c.maxCost > 0 && cost > c.maxCost {
+		return nil, ErrItemTooLarge
+	}
+
 	// Check for existing item
 	item, ok := c.items[key]
 	if ok {
+		// An update can grow an item's cost; evict other items first so
+		// the running total never exceeds MaxCost just from overwriting
+		// a resident key with a bigger payload.
+		if c.maxCost > 0 {
+			c.evictForCostExcept(item, cost)
+		}
+		c.currentCost += cost - item.cost
 		item.value = value
+		item.cost = cost
 	} else {
-		// Verify size not exceeded
-		if len(c.items) >= c.size {
+		// Verify size/cost not exceeded
+		if c.maxCost > 0 {
+			c.evictForCost(cost)
+		} else if len(c.items) >= c.size {
 			c.evict(1)
 		}
 		item = &lfuItem{
 			clock:       c.clock,
 			key:         key,
 			value:       value,
+			cost:        cost,
 			freqElement: nil,
 		}
 		el := c.freqList.Front()
@@ -87,6 +119,7 @@ func (c *LFUCache) set(key, value interface{}) (interface{}, error) {
 
 		item.freqElement = el
 		c.items[key] = item
+		c.currentCost += cost
 	}
 
 	if c.defaultTTL != nil {
@@ -136,7 +169,6 @@ func (c *LFUCache) get(key interface{}, onLoad bool) (interface{}, error) {
 
 func (c *LFUCache) getValue(key interface{}, onLoad bool) (interface{}, error) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 	item, ok := c.items[key]
 	if ok {
 		if !item.IsExpired(nil) {
@@ -150,6 +182,7 @@ func (c *LFUCache) getValue(key interface{}, onLoad bool) (interface{}, error) {
 		}
 		c.removeItem(item)
 	}
+	c.mu.Unlock()
 	if !onLoad {
 		c.stats.IncrMissCount()
 	}
@@ -185,8 +218,12 @@ func (c *LFUCache) increment(item *lfuItem) {
 	nextFreq := currentFreqEntry.freq + 1
 	delete(currentFreqEntry.items, item)
 
+	// Entries are kept in strictly increasing freq order, but with empty
+	// entries compacted away an item's next freq may not yet have an
+	// entry right after it, so only reuse Next() when it actually holds
+	// nextFreq; otherwise splice a new one in between.
 	nextFreqElement := currentFreqElement.Next()
-	if nextFreqElement == nil {
+	if nextFreqElement == nil || nextFreqElement.Value.(*freqEntry).freq != nextFreq {
 		nextFreqElement = c.freqList.InsertAfter(&freqEntry{
 			freq:  nextFreq,
 			items: make(map[*lfuItem]struct{}),
@@ -194,27 +231,130 @@ func (c *LFUCache) increment(item *lfuItem) {
 	}
 	nextFreqElement.Value.(*freqEntry).items[item] = struct{}{}
 	item.freqElement = nextFreqElement
+
+	c.compact(currentFreqElement, currentFreqEntry)
+}
+
+// compact removes a freqEntry from freqList once it holds no more items,
+// preserving the freq==0 entry as the list's permanent front sentinel so
+// evict and TopK always have a starting point to walk from.
+func (c *LFUCache) compact(el *list.Element, fe *freqEntry) {
+	if fe.freq != 0 && len(fe.items) == 0 {
+		c.freqList.Remove(el)
+	}
 }
 
-// evict removes the least frequent item from the cache.
+// evict removes the count least frequent items from the cache. It
+// re-walks from the front on each iteration since compaction in
+// removeItem may remove the very entry it is looking at.
 func (c *LFUCache) evict(count int) {
-	entry := c.freqList.Front()
-	for i := 0; i < count; {
-		if entry == nil {
+	for i := 0; i < count; i++ {
+		victim := c.leastFrequent()
+		if victim == nil {
 			return
 		}
+		c.removeItem(victim)
+		if c.onEvict != nil {
+			c.onEvict(victim.key, victim.value)
+		}
+	}
+}
+
+// leastFrequent returns any item from the lowest non-empty freqEntry, or
+// nil if the cache is empty.
+func (c *LFUCache) leastFrequent() *lfuItem {
+	for entry := c.freqList.Front(); entry != nil; entry = entry.Next() {
+		for item := range entry.Value.(*freqEntry).items {
+			return item
+		}
+	}
+	return nil
+}
+
+// leastFrequentExcept is leastFrequent but skips over except, so a
+// cost-driven eviction can never pick the very item it's making room for.
+func (c *LFUCache) leastFrequentExcept(except *lfuItem) *lfuItem {
+	for entry := c.freqList.Front(); entry != nil; entry = entry.Next() {
 		for item := range entry.Value.(*freqEntry).items {
-			if i >= count {
-				return
+			if item == except {
+				continue
 			}
-			c.removeItem(item)
-			if c.onEvict != nil {
-				c.onEvict(item.key, item.value)
+			return item
+		}
+	}
+	return nil
+}
+
+// evictForCost evicts least-frequent items, one at a time, until there is
+// room for an incoming item of the given cost under MaxCost.
+func (c *LFUCache) evictForCost(cost int64) {
+	for c.currentCost+cost > c.maxCost {
+		victim := c.leastFrequent()
+		if victim == nil {
+			return
+		}
+		c.removeItem(victim)
+		if c.onEvict != nil {
+			c.onEvict(victim.key, victim.value)
+		}
+	}
+}
+
+// evictForCostExcept is evictForCost's counterpart for the update path:
+// except is being resized to newCost in place, so it must never be
+// evicted to make room for itself.
+func (c *LFUCache) evictForCostExcept(except *lfuItem, newCost int64) {
+	for c.currentCost-except.cost+newCost > c.maxCost {
+		victim := c.leastFrequentExcept(except)
+		if victim == nil {
+			return
+		}
+		c.removeItem(victim)
+		if c.onEvict != nil {
+			c.onEvict(victim.key, victim.value)
+		}
+	}
+}
+
+// Cost returns the total cost of all live items currently in the cache,
+// as measured by Config.CostFunc.
+func (c *LFUCache) Cost() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.currentCost
+}
+
+// Frequency returns how many times key has been accessed via Get since it
+// was added, and whether key is present in the cache at all.
+func (c *LFUCache) Frequency(key interface{}) (uint, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	item, ok := c.items[key]
+	if !ok {
+		return 0, false
+	}
+	return item.freqElement.Value.(*freqEntry).freq, true
+}
+
+// TopK returns up to k of the cache's current keys ordered from
+// most-frequent to least-frequent, by walking freqList from its back
+// (highest freq) towards the front sentinel.
+func (c *LFUCache) TopK(k int) []interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if k <= 0 {
+		return nil
+	}
+	keys := make([]interface{}, 0, k)
+	for entry := c.freqList.Back(); entry != nil; entry = entry.Prev() {
+		for item := range entry.Value.(*freqEntry).items {
+			if len(keys) >= k {
+				return keys
 			}
-			i++
+			keys = append(keys, item.key)
 		}
-		entry = entry.Next()
 	}
+	return keys
 }
 
 // Has checks if key exists in cache
@@ -254,7 +394,10 @@ func (c *LFUCache) remove(key interface{}) bool {
 // removeElement is used to remove a given list element from the cache
 func (c *LFUCache) removeItem(item *lfuItem) {
 	delete(c.items, item.key)
-	delete(item.freqElement.Value.(*freqEntry).items, item)
+	fe := item.freqElement.Value.(*freqEntry)
+	delete(fe.items, item)
+	c.currentCost -= item.cost
+	c.compact(item.freqElement, fe)
 }
 
 func (c *LFUCache) keys() []interface{} {
@@ -337,6 +480,7 @@ type lfuItem struct {
 	clock       Clock
 	key         interface{}
 	value       interface{}
+	cost        int64
 	freqElement *list.Element
 	expiration  *time.Time
 }
@@ -0,0 +1,131 @@
+package gcache
+
+import "testing"
+
+func TestLFUBasicGetSet(t *testing.T) {
+	c := NewLFU(Config{Size: 4})
+	_ = c.Set("a", 1)
+	v, err := c.getValue("a", false)
+	if err != nil || v != 1 {
+		t.Fatalf("getValue(a) = %v, %v; want 1, nil", v, err)
+	}
+	if _, err := c.getValue("missing", false); err != ErrKeyNotFound {
+		t.Fatalf("getValue(missing) err = %v; want ErrKeyNotFound", err)
+	}
+}
+
+func TestLFUGetDoesNotDoubleUnlock(t *testing.T) {
+	c := NewLFU(Config{Size: 2})
+	_ = c.Set("a", 1)
+	if _, err := c.getValue("a", false); err != nil {
+		t.Fatalf("unexpected error on hit: %v", err)
+	}
+	if _, err := c.getValue("missing", false); err != ErrKeyNotFound {
+		t.Fatalf("unexpected error on miss: %v", err)
+	}
+}
+
+func byteCost(_, value interface{}) int64 {
+	return int64(len(value.(string)))
+}
+
+func TestLFUEvictsByCostOnInsert(t *testing.T) {
+	c := NewLFU(Config{Size: 100, MaxCost: 10, CostFunc: byteCost})
+	_ = c.Set("a", "12345") // cost 5
+	_ = c.Set("b", "12345") // cost 5, currentCost = 10
+	_ = c.Set("c", "12345") // needs room: evicts one of a/b (tied at freq 0)
+
+	if c.Cost() > 10 {
+		t.Fatalf("Cost() = %d; want <= 10", c.Cost())
+	}
+	if got := len(c.items); got != 2 {
+		t.Fatalf("len(items) = %d; want 2 (one of a/b evicted to make room for c)", got)
+	}
+	if _, ok := c.items["c"]; !ok {
+		t.Fatalf("\"c\" should be present")
+	}
+}
+
+func TestLFUEvictsByCostOnUpdate(t *testing.T) {
+	c := NewLFU(Config{Size: 100, MaxCost: 10, CostFunc: byteCost})
+	_ = c.Set("a", "12345")      // cost 5
+	_ = c.Set("b", "12345")      // cost 5, currentCost = 10
+	_ = c.Set("a", "1234567890") // grows a's cost to 10, must evict b first
+
+	if c.Cost() > 10 {
+		t.Fatalf("Cost() = %d; want <= 10 after growing an existing key", c.Cost())
+	}
+	if _, ok := c.items["b"]; ok {
+		t.Fatalf("\"b\" should have been evicted to make room for \"a\"'s larger value")
+	}
+	if v, err := c.getValue("a", false); err != nil || v != "1234567890" {
+		t.Fatalf("getValue(a) = %v, %v; want 1234567890, nil", v, err)
+	}
+}
+
+func TestLFUItemLargerThanMaxCostRejected(t *testing.T) {
+	c := NewLFU(Config{Size: 100, MaxCost: 5, CostFunc: byteCost})
+	if err := c.Set("a", "too long"); err != ErrItemTooLarge {
+		t.Fatalf("Set err = %v; want ErrItemTooLarge", err)
+	}
+}
+
+func TestLFUFrequency(t *testing.T) {
+	c := NewLFU(Config{Size: 10})
+	_ = c.Set("a", 1)
+	if _, ok := c.Frequency("missing"); ok {
+		t.Fatalf("Frequency(missing) ok = true; want false")
+	}
+	if freq, ok := c.Frequency("a"); !ok || freq != 0 {
+		t.Fatalf("Frequency(a) = %d, %v; want 0, true", freq, ok)
+	}
+	_, _ = c.getValue("a", false)
+	_, _ = c.getValue("a", false)
+	if freq, ok := c.Frequency("a"); !ok || freq != 2 {
+		t.Fatalf("Frequency(a) = %d, %v; want 2, true", freq, ok)
+	}
+}
+
+func TestLFUTopK(t *testing.T) {
+	c := NewLFU(Config{Size: 10})
+	_ = c.Set("a", 1)
+	_ = c.Set("b", 1)
+	_ = c.Set("c", 1)
+
+	for i := 0; i < 3; i++ {
+		_, _ = c.getValue("c", false)
+	}
+	for i := 0; i < 2; i++ {
+		_, _ = c.getValue("b", false)
+	}
+	_, _ = c.getValue("a", false)
+
+	if got := c.TopK(0); got != nil {
+		t.Fatalf("TopK(0) = %v; want nil", got)
+	}
+	got := c.TopK(2)
+	if len(got) != 2 {
+		t.Fatalf("len(TopK(2)) = %d; want 2", len(got))
+	}
+	if got[0] != "c" || got[1] != "b" {
+		t.Fatalf("TopK(2) = %v; want [c b] (most-frequent first)", got)
+	}
+}
+
+// TestLFUFreqListCompactsEmptyEntries checks that freqEntry nodes are
+// removed from freqList as soon as they go empty, rather than
+// accumulating one per distinct frequency ever reached: repeatedly
+// bouncing a single item's frequency up must not grow freqList without
+// bound.
+func TestLFUFreqListCompactsEmptyEntries(t *testing.T) {
+	c := NewLFU(Config{Size: 10})
+	_ = c.Set("a", 1)
+	for i := 0; i < 50; i++ {
+		_, _ = c.getValue("a", false)
+	}
+	// Only the freq==0 sentinel (now empty) and "a"'s current freq entry
+	// should remain.
+	if got := c.freqList.Len(); got != 2 {
+		t.Fatalf("freqList.Len() = %d; want 2 (sentinel + a's entry)", got)
+	}
+}
@@ -0,0 +1,163 @@
+package gcache
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+)
+
+// ShardedCache wraps a fixed number of independent Cache shards, each
+// guarded by its own mutex, so that Get/Set contention drops roughly
+// linearly with the shard count. Keys are routed to a shard by hashing
+// with FNV-1a, and reads that need the whole keyspace (Purge, Keys,
+// GetALL, Len) simply fan out to every shard.
+type ShardedCache struct {
+	shards []Cache
+}
+
+// NewSharded returns a new ShardedCache with the given number of shards,
+// each built from config with its size and cost budget divided evenly
+// across shards, so the aggregate bounds match config.Size/config.MaxCost
+// rather than multiplying them by the shard count. The underlying
+// per-shard cache policy is selected by config.EvictType, defaulting to
+// LFU.
+func NewSharded(config Config, shards int) *ShardedCache {
+	if shards <= 0 {
+		shards = 1
+	}
+
+	shardConfig := config
+	shardConfig.Size = (config.Size + shards - 1) / shards
+	if config.MaxCost > 0 {
+		shardConfig.MaxCost = (config.MaxCost + int64(shards) - 1) / int64(shards)
+	}
+
+	c := &ShardedCache{
+		shards: make([]Cache, shards),
+	}
+	for i := 0; i < shards; i++ {
+		c.shards[i] = newShard(shardConfig)
+	}
+	return c
+}
+
+func newShard(config Config) Cache {
+	switch config.EvictType {
+	case TYPE_ARC:
+		return NewARC(config)
+	case TYPE_2Q:
+		return NewTwoQueue(config)
+	case TYPE_SIEVE:
+		return NewSieve(config)
+	default:
+		return NewLFU(config)
+	}
+}
+
+func (c *ShardedCache) shardFor(key interface{}) Cache {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%v", key)
+	return c.shards[h.Sum32()%uint32(len(c.shards))]
+}
+
+// Set a new key-value pair
+func (c *ShardedCache) Set(key, value interface{}) error {
+	return c.shardFor(key).Set(key, value)
+}
+
+// SetWithTTL Set a new key-value pair with an expiration time
+func (c *ShardedCache) SetWithTTL(key, value interface{}, expiration time.Duration) error {
+	return c.shardFor(key).SetWithTTL(key, value, expiration)
+}
+
+// Get a value from cache pool using key if it exists.
+// If it dose not exists key and has LoaderFunc,
+// generate a value using `LoaderFunc` method returns value.
+func (c *ShardedCache) Get(key interface{}) (interface{}, error) {
+	return c.shardFor(key).Get(key)
+}
+
+// GetIFPresent gets a value from cache pool using key if it exists.
+// On a miss it behaves as the owning shard's GetIFPresent does.
+func (c *ShardedCache) GetIFPresent(key interface{}) (interface{}, error) {
+	return c.shardFor(key).GetIFPresent(key)
+}
+
+// Has checks if key exists in cache
+func (c *ShardedCache) Has(key interface{}) bool {
+	return c.shardFor(key).Has(key)
+}
+
+// Remove removes the provided key from the cache.
+func (c *ShardedCache) Remove(key interface{}) bool {
+	return c.shardFor(key).Remove(key)
+}
+
+// Purge completely clears every shard
+func (c *ShardedCache) Purge() {
+	for _, s := range c.shards {
+		s.Purge()
+	}
+}
+
+// Keys returns a slice of the keys across all shards
+func (c *ShardedCache) Keys(checkExpired bool) []interface{} {
+	keys := make([]interface{}, 0)
+	for _, s := range c.shards {
+		keys = append(keys, s.Keys(checkExpired)...)
+	}
+	return keys
+}
+
+// GetALL returns all key-value pairs across all shards.
+func (c *ShardedCache) GetALL(checkExpired bool) map[interface{}]interface{} {
+	items := make(map[interface{}]interface{})
+	for _, s := range c.shards {
+		for k, v := range s.GetALL(checkExpired) {
+			items[k] = v
+		}
+	}
+	return items
+}
+
+// Len returns the number of items across all shards
+func (c *ShardedCache) Len(checkExpired bool) int {
+	var length int
+	for _, s := range c.shards {
+		length += s.Len(checkExpired)
+	}
+	return length
+}
+
+// HitCount returns the aggregate cache hit count across all shards
+func (c *ShardedCache) HitCount() uint64 {
+	var total uint64
+	for _, s := range c.shards {
+		total += s.HitCount()
+	}
+	return total
+}
+
+// MissCount returns the aggregate cache miss count across all shards
+func (c *ShardedCache) MissCount() uint64 {
+	var total uint64
+	for _, s := range c.shards {
+		total += s.MissCount()
+	}
+	return total
+}
+
+// LookupCount returns the aggregate number of lookups across all shards
+func (c *ShardedCache) LookupCount() uint64 {
+	return c.HitCount() + c.MissCount()
+}
+
+// HitRate returns the aggregate cache hit rate across all shards
+func (c *ShardedCache) HitRate() float64 {
+	hc, mc := c.HitCount(), c.MissCount()
+	total := hc + mc
+	if total == 0 {
+		return 0.0
+	}
+	return float64(hc) / float64(total)
+}
@@ -0,0 +1,91 @@
+package gcache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShardedDistributesAndAggregates(t *testing.T) {
+	c := NewSharded(Config{
+		Size: 100,
+		LoaderFunc: func(key interface{}) (interface{}, *time.Duration, error) {
+			return nil, nil, ErrKeyNotFound
+		},
+	}, 4)
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	for i, k := range keys {
+		if err := c.Set(k, i); err != nil {
+			t.Fatalf("Set(%s): %v", k, err)
+		}
+	}
+	for i, k := range keys {
+		v, err := c.GetIFPresent(k)
+		if err != nil || v != i {
+			t.Fatalf("GetIFPresent(%s) = %v, %v; want %d, nil", k, v, err, i)
+		}
+	}
+
+	if got := c.Len(false); got != len(keys) {
+		t.Fatalf("Len = %d; want %d", got, len(keys))
+	}
+	if got := len(c.Keys(false)); got != len(keys) {
+		t.Fatalf("len(Keys) = %d; want %d", got, len(keys))
+	}
+	if got := len(c.GetALL(false)); got != len(keys) {
+		t.Fatalf("len(GetALL) = %d; want %d", got, len(keys))
+	}
+
+	if got := c.HitCount(); got != uint64(len(keys)) {
+		t.Fatalf("HitCount = %d; want %d", got, len(keys))
+	}
+	if _, err := c.GetIFPresent("missing"); err != ErrKeyNotFound {
+		t.Fatalf("GetIFPresent(missing) err = %v; want ErrKeyNotFound", err)
+	}
+	if got := c.MissCount(); got != 1 {
+		t.Fatalf("MissCount = %d; want 1", got)
+	}
+	if got := c.LookupCount(); got != uint64(len(keys))+1 {
+		t.Fatalf("LookupCount = %d; want %d", got, len(keys)+1)
+	}
+
+	if !c.Remove("a") {
+		t.Fatalf("Remove(a) = false; want true")
+	}
+	if c.Has("a") {
+		t.Fatalf("Has(a) = true after Remove")
+	}
+
+	c.Purge()
+	if got := c.Len(false); got != 0 {
+		t.Fatalf("Len after Purge = %d; want 0", got)
+	}
+}
+
+func TestShardedHonorsEvictType(t *testing.T) {
+	c := NewSharded(Config{Size: 8, EvictType: TYPE_ARC}, 2)
+	for _, s := range c.shards {
+		if _, ok := s.(*ARCCache); !ok {
+			t.Fatalf("shard type = %T; want *ARCCache", s)
+		}
+	}
+}
+
+// TestShardedDividesMaxCostAcrossShards checks that a cost budget set on
+// the aggregate config is split per shard like Size is, so the effective
+// global budget stays MaxCost rather than becoming shards*MaxCost.
+func TestShardedDividesMaxCostAcrossShards(t *testing.T) {
+	c := NewSharded(Config{
+		Size:     100,
+		MaxCost:  40,
+		CostFunc: func(key, value interface{}) int64 { return int64(len(value.(string))) },
+	}, 4)
+	for _, s := range c.shards {
+		lfu, ok := s.(*LFUCache)
+		if !ok {
+			t.Fatalf("shard type = %T; want *LFUCache", s)
+		}
+		if lfu.maxCost != 10 {
+			t.Fatalf("shard maxCost = %d; want 10 (40 / 4 shards)", lfu.maxCost)
+		}
+	}
+}
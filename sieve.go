@@ -0,0 +1,337 @@
+package gcache
+
+import (
+	"container/list"
+	"time"
+)
+
+// SieveCache represents a cache which uses the SIEVE eviction policy: a
+// single FIFO list of entries each carrying one "visited" bit, and a
+// "hand" pointer that sweeps the list looking for something to evict.
+// Unlike LFU, a Get only flips a bit rather than mutating the list, so
+// the hit path does no list surgery and needs no freq-list bookkeeping,
+// making it a lower-contention alternative on read-heavy workloads.
+type SieveCache struct {
+	baseCache
+	items map[interface{}]*sieveItem
+	ll    *list.List // FIFO list, new items pushed to the front
+	hand  *list.Element
+}
+
+// NewSieve returns new SIEVE cache instance
+func NewSieve(config Config) *SieveCache {
+	return newSieveCache(config)
+}
+
+func newSieveCache(config Config) *SieveCache {
+	c := &SieveCache{}
+	buildCache(&c.baseCache, config)
+
+	c.init()
+	c.loadGroup.cache = c
+	return c
+}
+
+func (c *SieveCache) init() {
+	c.items = make(map[interface{}]*sieveItem, c.size+1)
+	c.ll = list.New()
+	c.hand = nil
+}
+
+// Set a new key-value pair
+func (c *SieveCache) Set(key, value interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.set(key, value)
+	return err
+}
+
+// SetWithTTL Set a new key-value pair with an expiration time
+func (c *SieveCache) SetWithTTL(key, value interface{}, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, err := c.set(key, value)
+	if err != nil {
+		return err
+	}
+
+	t := c.clock.Now().Add(expiration)
+	item.(*sieveItem).expiration = &t
+	return nil
+}
+
+func (c *SieveCache) set(key, value interface{}) (interface{}, error) {
+	var err error
+	if c.serializeWith != nil {
+		value, err = c.serializeWith(key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item, ok := c.items[key]
+	if ok {
+		item.value = value
+	} else {
+		if len(c.items) >= c.size {
+			c.evict()
+		}
+		item = &sieveItem{
+			clock:   c.clock,
+			key:     key,
+			value:   value,
+			visited: false,
+		}
+		item.element = c.ll.PushFront(item)
+		c.items[key] = item
+	}
+
+	if c.defaultTTL != nil {
+		t := c.clock.Now().Add(*c.defaultTTL)
+		item.expiration = &t
+	}
+
+	if c.onAdd != nil {
+		c.onAdd(key, value)
+	}
+
+	return item, nil
+}
+
+// Get a value from cache pool using key if it exists.
+// If it dose not exists key and has LoaderFunc,
+// generate a value using `LoaderFunc` method returns value.
+func (c *SieveCache) Get(key interface{}) (interface{}, error) {
+	v, err := c.get(key, false)
+	if err == ErrKeyNotFound {
+		return c.getWithLoader(key, true)
+	}
+	return v, err
+}
+
+// GetIFPresent gets a value from cache pool using key if it exists.
+// If it does not exist and the cache has a LoaderFunc, it loads the value
+// without waiting on a load already in flight for the same key (returning
+// nil, nil in that case); without a LoaderFunc it returns ErrKeyNotFound.
+func (c *SieveCache) GetIFPresent(key interface{}) (interface{}, error) {
+	v, err := c.get(key, false)
+	if err == ErrKeyNotFound {
+		return c.getWithLoader(key, false)
+	}
+	return v, err
+}
+
+func (c *SieveCache) get(key interface{}, onLoad bool) (interface{}, error) {
+	v, err := c.getValue(key, onLoad)
+	if err != nil {
+		return nil, err
+	}
+	if c.deserializeWith != nil {
+		return c.deserializeWith(key, v)
+	}
+	return v, nil
+}
+
+func (c *SieveCache) getValue(key interface{}, onLoad bool) (interface{}, error) {
+	c.mu.Lock()
+	item, ok := c.items[key]
+	if ok {
+		if !item.IsExpired(nil) {
+			item.visited = true
+			v := item.value
+			c.mu.Unlock()
+			if !onLoad {
+				c.stats.IncrHitCount()
+			}
+			return v, nil
+		}
+		c.removeItem(item)
+	}
+	c.mu.Unlock()
+	if !onLoad {
+		c.stats.IncrMissCount()
+	}
+	return nil, ErrKeyNotFound
+}
+
+func (c *SieveCache) getWithLoader(key interface{}, isWait bool) (interface{}, error) {
+	value, _, err := c.load(key, func(v interface{}, expiration *time.Duration, e error) (interface{}, error) {
+		if e != nil {
+			return nil, e
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		item, err := c.set(key, v)
+		if err != nil {
+			return nil, err
+		}
+		if expiration != nil {
+			t := c.clock.Now().Add(*expiration)
+			item.(*sieveItem).expiration = &t
+		}
+		return v, nil
+	}, isWait)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// evict walks the list from the hand towards the head, clearing visited
+// bits along the way, until it finds an unvisited item to evict. The
+// hand is left just before the evicted item so the next sweep resumes
+// from there.
+func (c *SieveCache) evict() {
+	el := c.hand
+	if el == nil {
+		el = c.ll.Back()
+	}
+	for el != nil {
+		item := el.Value.(*sieveItem)
+		if item.visited {
+			item.visited = false
+			el = el.Prev()
+			if el == nil {
+				el = c.ll.Back()
+			}
+			continue
+		}
+		c.hand = el.Prev()
+		c.ll.Remove(el)
+		delete(c.items, item.key)
+		if c.onEvict != nil {
+			c.onEvict(item.key, item.value)
+		}
+		return
+	}
+}
+
+// Has checks if key exists in cache
+func (c *SieveCache) Has(key interface{}) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	now := time.Now()
+	return c.has(key, &now)
+}
+
+func (c *SieveCache) has(key interface{}, now *time.Time) bool {
+	item, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	return !item.IsExpired(now)
+}
+
+// Remove removes the provided key from the cache.
+func (c *SieveCache) Remove(key interface{}) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if item, ok := c.items[key]; ok {
+		c.removeItem(item)
+		if c.onDel != nil {
+			c.onDel(item.key, item.value)
+		}
+		return true
+	}
+	return false
+}
+
+func (c *SieveCache) removeItem(item *sieveItem) {
+	if c.hand == item.element {
+		c.hand = item.element.Prev()
+	}
+	delete(c.items, item.key)
+	c.ll.Remove(item.element)
+}
+
+func (c *SieveCache) keys() []interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys := make([]interface{}, len(c.items))
+	var i = 0
+	for k := range c.items {
+		keys[i] = k
+		i++
+	}
+	return keys
+}
+
+// GetALL returns all key-value pairs in the cache.
+func (c *SieveCache) GetALL(checkExpired bool) map[interface{}]interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	items := make(map[interface{}]interface{}, len(c.items))
+	now := time.Now()
+	for k, item := range c.items {
+		if !checkExpired || c.has(k, &now) {
+			items[k] = item.value
+		}
+	}
+	return items
+}
+
+// Keys returns a slice of the keys in the cache
+func (c *SieveCache) Keys(checkExpired bool) []interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys := make([]interface{}, 0, len(c.items))
+	now := time.Now()
+	for k := range c.items {
+		if !checkExpired || c.has(k, &now) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Len returns the number of items in the cache
+func (c *SieveCache) Len(checkExpired bool) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !checkExpired {
+		return len(c.items)
+	}
+	var length int
+	now := time.Now()
+	for k := range c.items {
+		if c.has(k, &now) {
+			length++
+		}
+	}
+	return length
+}
+
+// Purge completely clears the cache
+func (c *SieveCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.onPurge != nil {
+		for key, item := range c.items {
+			c.onPurge(key, item.value)
+		}
+	}
+
+	c.init()
+}
+
+type sieveItem struct {
+	clock      Clock
+	key        interface{}
+	value      interface{}
+	visited    bool
+	element    *list.Element
+	expiration *time.Time
+}
+
+// IsExpired returns boolean value whether this item is expired or not
+func (it *sieveItem) IsExpired(now *time.Time) bool {
+	if it.expiration == nil {
+		return false
+	}
+	if now == nil {
+		t := it.clock.Now()
+		now = &t
+	}
+	return it.expiration.Before(*now)
+}
@@ -0,0 +1,417 @@
+package gcache
+
+import (
+	"container/list"
+	"time"
+)
+
+// Default fraction of the total cache size given to the `recent` (A1in)
+// and `recentEvict` (A1out, ghost) queues when the config does not
+// override them.
+const (
+	TwoQueueRecentRatio = 0.25
+	TwoQueueGhostRatio  = 0.50
+)
+
+// TwoQueueCache represents a cache which uses the 2Q eviction policy. It
+// separates one-time-seen entries (recent) from entries accessed more
+// than once (frequent), using a ghost queue of recently-evicted recent
+// keys to decide when a key should be promoted straight into frequent.
+type TwoQueueCache struct {
+	baseCache
+	items map[interface{}]*twoQueueItem
+
+	recentRatio float64
+	ghostRatio  float64
+	recentSize  int
+
+	recent      *list.List // A1in: one-time-seen entries
+	frequent    *list.List // Am: promoted entries
+	recentEvict *list.List // A1out: ghost keys evicted from recent
+
+	// recentEvictIndex backs recentEvict with an O(1) membership lookup,
+	// instead of scanning the ghost list.
+	recentEvictIndex map[interface{}]*list.Element
+}
+
+// NewTwoQueue returns new 2Q cache instance
+func NewTwoQueue(config Config) *TwoQueueCache {
+	return newTwoQueueCache(config)
+}
+
+func newTwoQueueCache(config Config) *TwoQueueCache {
+	c := &TwoQueueCache{}
+	buildCache(&c.baseCache, config)
+
+	c.recentRatio = config.RecentRatio
+	if c.recentRatio <= 0 {
+		c.recentRatio = TwoQueueRecentRatio
+	}
+	c.ghostRatio = config.GhostRatio
+	if c.ghostRatio <= 0 {
+		c.ghostRatio = TwoQueueGhostRatio
+	}
+
+	c.init()
+	c.loadGroup.cache = c
+	return c
+}
+
+func (c *TwoQueueCache) init() {
+	c.recentSize = int(float64(c.size) * c.recentRatio)
+	c.items = make(map[interface{}]*twoQueueItem, c.size+1)
+	c.recent = list.New()
+	c.frequent = list.New()
+	c.recentEvict = list.New()
+	c.recentEvictIndex = make(map[interface{}]*list.Element, c.size+1)
+}
+
+// Set a new key-value pair
+func (c *TwoQueueCache) Set(key, value interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.set(key, value)
+	return err
+}
+
+// SetWithTTL Set a new key-value pair with an expiration time
+func (c *TwoQueueCache) SetWithTTL(key, value interface{}, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, err := c.set(key, value)
+	if err != nil {
+		return err
+	}
+
+	t := c.clock.Now().Add(expiration)
+	item.(*twoQueueItem).expiration = &t
+	return nil
+}
+
+func (c *TwoQueueCache) set(key, value interface{}) (interface{}, error) {
+	var err error
+	if c.serializeWith != nil {
+		value, err = c.serializeWith(key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if item, ok := c.items[key]; ok {
+		item.value = value
+		c.promote(item)
+		return c.finishSet(key, item), nil
+	}
+
+	if el := c.lookupGhost(c.recentEvictIndex, key); el != nil {
+		c.recentEvict.Remove(el)
+		delete(c.recentEvictIndex, key)
+		c.ensureSpace()
+		item := c.newItem(key, value)
+		item.element = c.frequent.PushFront(item)
+		item.ls = c.frequent
+		c.items[key] = item
+		return c.finishSet(key, item), nil
+	}
+
+	c.ensureSpace()
+	item := c.newItem(key, value)
+	item.element = c.recent.PushFront(item)
+	item.ls = c.recent
+	c.items[key] = item
+	return c.finishSet(key, item), nil
+}
+
+func (c *TwoQueueCache) finishSet(key interface{}, item *twoQueueItem) *twoQueueItem {
+	if c.defaultTTL != nil {
+		t := c.clock.Now().Add(*c.defaultTTL)
+		item.expiration = &t
+	}
+	if c.onAdd != nil {
+		c.onAdd(key, item.value)
+	}
+	return item
+}
+
+// ensureSpace evicts one entry when the cache is full: from recent if it
+// holds at least its recentSize share, otherwise from frequent (or from
+// recent when frequent is empty).
+func (c *TwoQueueCache) ensureSpace() {
+	if c.recent.Len()+c.frequent.Len() < c.size {
+		return
+	}
+	if c.recent.Len() > 0 && c.recent.Len() >= c.recentSize {
+		c.evictRecentToGhost()
+		return
+	}
+	if c.frequent.Len() > 0 {
+		c.evictFrequent()
+		return
+	}
+	c.evictRecentToGhost()
+}
+
+func (c *TwoQueueCache) evictRecentToGhost() {
+	el := c.recent.Back()
+	if el == nil {
+		return
+	}
+	item := el.Value.(*twoQueueItem)
+	c.recent.Remove(el)
+	delete(c.items, item.key)
+	c.recentEvictIndex[item.key] = c.recentEvict.PushFront(item.key)
+	for c.recentEvict.Len() > int(float64(c.size)*c.ghostRatio) {
+		back := c.recentEvict.Back()
+		c.recentEvict.Remove(back)
+		delete(c.recentEvictIndex, back.Value)
+	}
+	if c.onEvict != nil {
+		c.onEvict(item.key, item.value)
+	}
+}
+
+func (c *TwoQueueCache) evictFrequent() {
+	el := c.frequent.Back()
+	if el == nil {
+		return
+	}
+	item := el.Value.(*twoQueueItem)
+	c.frequent.Remove(el)
+	delete(c.items, item.key)
+	if c.onEvict != nil {
+		c.onEvict(item.key, item.value)
+	}
+}
+
+// lookupGhost reports whether key is present in the recentEvict ghost
+// list via its backing index map, in O(1) rather than scanning the list.
+func (c *TwoQueueCache) lookupGhost(ghostIndex map[interface{}]*list.Element, key interface{}) *list.Element {
+	return ghostIndex[key]
+}
+
+func (c *TwoQueueCache) newItem(key, value interface{}) *twoQueueItem {
+	return &twoQueueItem{
+		clock: c.clock,
+		key:   key,
+		value: value,
+	}
+}
+
+// promote moves a one-time-seen entry from recent into frequent on its
+// second access, whether that access came via Get or Set; an entry
+// already in frequent just moves to the MRU position.
+func (c *TwoQueueCache) promote(item *twoQueueItem) {
+	if item.ls == c.recent {
+		c.recent.Remove(item.element)
+		item.element = c.frequent.PushFront(item)
+		item.ls = c.frequent
+	} else {
+		c.frequent.MoveToFront(item.element)
+	}
+}
+
+// Get a value from cache pool using key if it exists.
+// If it dose not exists key and has LoaderFunc,
+// generate a value using `LoaderFunc` method returns value.
+func (c *TwoQueueCache) Get(key interface{}) (interface{}, error) {
+	v, err := c.get(key, false)
+	if err == ErrKeyNotFound {
+		return c.getWithLoader(key, true)
+	}
+	return v, err
+}
+
+// GetIFPresent gets a value from cache pool using key if it exists.
+// If it does not exist and the cache has a LoaderFunc, it loads the value
+// without waiting on a load already in flight for the same key (returning
+// nil, nil in that case); without a LoaderFunc it returns ErrKeyNotFound.
+func (c *TwoQueueCache) GetIFPresent(key interface{}) (interface{}, error) {
+	v, err := c.get(key, false)
+	if err == ErrKeyNotFound {
+		return c.getWithLoader(key, false)
+	}
+	return v, err
+}
+
+func (c *TwoQueueCache) get(key interface{}, onLoad bool) (interface{}, error) {
+	v, err := c.getValue(key, onLoad)
+	if err != nil {
+		return nil, err
+	}
+	if c.deserializeWith != nil {
+		return c.deserializeWith(key, v)
+	}
+	return v, nil
+}
+
+func (c *TwoQueueCache) getValue(key interface{}, onLoad bool) (interface{}, error) {
+	c.mu.Lock()
+	item, ok := c.items[key]
+	if ok {
+		if !item.IsExpired(nil) {
+			c.promote(item)
+			v := item.value
+			c.mu.Unlock()
+			if !onLoad {
+				c.stats.IncrHitCount()
+			}
+			return v, nil
+		}
+		c.removeItem(item)
+	}
+	c.mu.Unlock()
+	if !onLoad {
+		c.stats.IncrMissCount()
+	}
+	return nil, ErrKeyNotFound
+}
+
+func (c *TwoQueueCache) getWithLoader(key interface{}, isWait bool) (interface{}, error) {
+	value, _, err := c.load(key, func(v interface{}, expiration *time.Duration, e error) (interface{}, error) {
+		if e != nil {
+			return nil, e
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		item, err := c.set(key, v)
+		if err != nil {
+			return nil, err
+		}
+		if expiration != nil {
+			t := c.clock.Now().Add(*expiration)
+			item.(*twoQueueItem).expiration = &t
+		}
+		return v, nil
+	}, isWait)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Has checks if key exists in cache
+func (c *TwoQueueCache) Has(key interface{}) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	now := time.Now()
+	return c.has(key, &now)
+}
+
+func (c *TwoQueueCache) has(key interface{}, now *time.Time) bool {
+	item, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	return !item.IsExpired(now)
+}
+
+// Remove removes the provided key from the cache.
+func (c *TwoQueueCache) Remove(key interface{}) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if item, ok := c.items[key]; ok {
+		c.removeItem(item)
+		if c.onDel != nil {
+			c.onDel(item.key, item.value)
+		}
+		return true
+	}
+	return false
+}
+
+func (c *TwoQueueCache) removeItem(item *twoQueueItem) {
+	delete(c.items, item.key)
+	item.ls.Remove(item.element)
+}
+
+func (c *TwoQueueCache) keys() []interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys := make([]interface{}, len(c.items))
+	var i = 0
+	for k := range c.items {
+		keys[i] = k
+		i++
+	}
+	return keys
+}
+
+// GetALL returns all key-value pairs in the cache.
+func (c *TwoQueueCache) GetALL(checkExpired bool) map[interface{}]interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	items := make(map[interface{}]interface{}, len(c.items))
+	now := time.Now()
+	for k, item := range c.items {
+		if !checkExpired || c.has(k, &now) {
+			items[k] = item.value
+		}
+	}
+	return items
+}
+
+// Keys returns a slice of the keys in the cache
+func (c *TwoQueueCache) Keys(checkExpired bool) []interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys := make([]interface{}, 0, len(c.items))
+	now := time.Now()
+	for k := range c.items {
+		if !checkExpired || c.has(k, &now) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Len returns the number of items in the cache
+func (c *TwoQueueCache) Len(checkExpired bool) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !checkExpired {
+		return len(c.items)
+	}
+	var length int
+	now := time.Now()
+	for k := range c.items {
+		if c.has(k, &now) {
+			length++
+		}
+	}
+	return length
+}
+
+// Purge completely clears the cache
+func (c *TwoQueueCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.onPurge != nil {
+		for key, item := range c.items {
+			c.onPurge(key, item.value)
+		}
+	}
+
+	c.init()
+}
+
+type twoQueueItem struct {
+	clock      Clock
+	key        interface{}
+	value      interface{}
+	ls         *list.List
+	element    *list.Element
+	expiration *time.Time
+}
+
+// IsExpired returns boolean value whether this item is expired or not
+func (it *twoQueueItem) IsExpired(now *time.Time) bool {
+	if it.expiration == nil {
+		return false
+	}
+	if now == nil {
+		t := it.clock.Now()
+		now = &t
+	}
+	return it.expiration.Before(*now)
+}
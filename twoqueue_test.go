@@ -0,0 +1,76 @@
+package gcache
+
+import "testing"
+
+func TestTwoQueueBasicGetSet(t *testing.T) {
+	c := NewTwoQueue(Config{Size: 4})
+	_ = c.Set("a", 1)
+	v, err := c.getValue("a", false)
+	if err != nil || v != 1 {
+		t.Fatalf("getValue(a) = %v, %v; want 1, nil", v, err)
+	}
+	if _, err := c.getValue("missing", false); err != ErrKeyNotFound {
+		t.Fatalf("getValue(missing) err = %v; want ErrKeyNotFound", err)
+	}
+}
+
+func TestTwoQueueGetPromotesRecentToFrequent(t *testing.T) {
+	c := NewTwoQueue(Config{Size: 4})
+	_ = c.Set("a", 1)
+	item := c.items["a"]
+	if item.ls != c.recent {
+		t.Fatalf("newly-set key should start in recent")
+	}
+	if _, err := c.getValue("a", false); err != nil {
+		t.Fatalf("getValue(a): %v", err)
+	}
+	if item.ls != c.frequent {
+		t.Fatalf("a second touch via Get should promote the key into frequent")
+	}
+}
+
+func TestTwoQueueSetPromotesRecentToFrequent(t *testing.T) {
+	c := NewTwoQueue(Config{Size: 4})
+	_ = c.Set("a", 1)
+	item := c.items["a"]
+	if item.ls != c.recent {
+		t.Fatalf("newly-set key should start in recent")
+	}
+	// A second Set on the same key, not just a Get, must also promote it;
+	// this is the bug the reviewer flagged: Set used to silently no-op for
+	// keys sitting in recent.
+	_ = c.Set("a", 2)
+	if item.ls != c.frequent {
+		t.Fatalf("a second touch via Set should promote the key into frequent")
+	}
+	v, _ := c.getValue("a", false)
+	if v != 2 {
+		t.Fatalf("value = %v; want 2", v)
+	}
+}
+
+func TestTwoQueueGhostHitPromotesStraightToFrequent(t *testing.T) {
+	c := NewTwoQueue(Config{Size: 4, RecentRatio: 0.25, GhostRatio: 1})
+	_ = c.Set("a", 1)
+	_ = c.Set("b", 1)
+	_ = c.Set("c", 1)
+	_ = c.Set("d", 1)
+	// The cache is now full, and recent holds more than its recentSize =
+	// size*0.25 = 1 share, so the next Set evicts "a" (the oldest entry in
+	// recent) into the recentEvict ghost queue.
+	_ = c.Set("e", 1)
+	if _, ok := c.recentEvictIndex["a"]; !ok {
+		t.Fatalf("expected \"a\" to have been evicted into the recentEvict ghost queue")
+	}
+	_ = c.Set("a", 2)
+	item, ok := c.items["a"]
+	if !ok {
+		t.Fatalf("\"a\" should be back in the cache after a ghost hit")
+	}
+	if item.ls != c.frequent {
+		t.Fatalf("a ghost hit should land the key directly in frequent, not recent")
+	}
+	if _, ok := c.recentEvictIndex["a"]; ok {
+		t.Fatalf("\"a\" should have been removed from the ghost index once reinstated")
+	}
+}